@@ -1,9 +1,9 @@
 package smugmug
 
 import (
+	"context"
 	"encoding/json"
-	"net/http"
-	"net/url"
+	"fmt"
 	"strings"
 	"time"
 )
@@ -18,9 +18,58 @@ func NewAlbumsService(s *Service) *AlbumsService {
 }
 
 func (r *AlbumsService) Get(id string) *AlbumsGetCall {
-	c := &AlbumsGetCall{s: r.s, urlParams: url.Values{}}
-	c.id = id
-	return c
+	return &AlbumsGetCall{newCall(r.s, "GET", "album/"+id, "Album", func(res *AlbumsGetResponse) interface{} { return &res.Album })}
+}
+
+func (r *AlbumsService) GetAlbum(ctx context.Context, albumKey string, opts ...CallOption) (*AlbumsGetResponse, error) {
+	c := r.Get(albumKey).Context(ctx)
+	applyOptions(c.urlParams, opts)
+	return c.Do()
+}
+
+// GetMany fetches many albums in as few requests as possible, keyed by
+// album key. Keys that fail are reported in a BatchErrors.
+func (r *AlbumsService) GetMany(ctx context.Context, albumKeys ...string) (map[string]*Album, error) {
+	return getMany(ctx, r.s, "album", albumKeys, func(a *Album) string { return a.AlbumKey })
+}
+
+// MoveImages moves the given album images, from any albums, into albumKey.
+// All URIs go in a single request; see AlbumImageURI.
+func (r *AlbumsService) MoveImages(albumKey string, imageURIs ...string) *AlbumsImagesActionCall {
+	return newAlbumsImagesAction(r.s, albumKey, "!moveimages", "MoveUris", imageURIs)
+}
+
+// CollectImages adds the given images to albumKey without removing them
+// from the albums they are in.
+func (r *AlbumsService) CollectImages(albumKey string, imageURIs ...string) *AlbumsImagesActionCall {
+	return newAlbumsImagesAction(r.s, albumKey, "!collectimages", "CollectUris", imageURIs)
+}
+
+// DeleteImages removes the given images from albumKey. Images that live in
+// albumKey are deleted; collected ones are only unlinked.
+func (r *AlbumsService) DeleteImages(albumKey string, imageURIs ...string) *AlbumsImagesActionCall {
+	return newAlbumsImagesAction(r.s, albumKey, "!deleteimages", "AlbumImageUris", imageURIs)
+}
+
+func (r *AlbumsService) MoveAlbumImages(ctx context.Context, albumKey string, imageURIs ...string) error {
+	return r.MoveImages(albumKey, imageURIs...).Context(ctx).Do()
+}
+
+func (r *AlbumsService) CollectAlbumImages(ctx context.Context, albumKey string, imageURIs ...string) error {
+	return r.CollectImages(albumKey, imageURIs...).Context(ctx).Do()
+}
+
+func (r *AlbumsService) DeleteAlbumImages(ctx context.Context, albumKey string, imageURIs ...string) error {
+	return r.DeleteImages(albumKey, imageURIs...).Context(ctx).Do()
+}
+
+// AlbumImageURI returns the URI of image imageKey as a member of album
+// albumKey, the form MoveImages, CollectImages and DeleteImages expect.
+func AlbumImageURI(albumKey, imageKey string) string {
+	if trimSerial(imageKey) == imageKey {
+		imageKey += "-0"
+	}
+	return "/api/v2/album/" + albumKey + "/image/" + imageKey
 }
 
 type AlbumsServiceResponse struct {
@@ -34,81 +83,79 @@ type AlbumsServiceResponse struct {
 }
 
 type AlbumsGetCall struct {
-	id string
-
-	s         *Service
-	urlParams url.Values
+	*Call[AlbumsGetResponse]
 }
 
 func (c *AlbumsGetCall) Expand(expansions []string) *AlbumsGetCall {
-	c.urlParams.Set("_expand", strings.Join(expansions, ","))
+	c.Call.Expand(expansions)
 	return c
 }
 
 func (c *AlbumsGetCall) Filter(filter []string) *AlbumsGetCall {
-	c.urlParams.Set("_filter", strings.Join(filter, ","))
+	c.Call.Filter(filter)
 	return c
 }
 
-func (c *AlbumsGetCall) doRequest() (*http.Response, error) {
-	urls := resolveRelative(c.s.BasePath, "album/"+c.id)
-	urls += "?" + encodeURLParams(c.urlParams)
-	req, _ := http.NewRequest("GET", urls, nil)
-	c.s.setHeaders(req)
-	debugRequest(req)
-	return c.s.client.Do(req)
+func (c *AlbumsGetCall) Fields(fields ...AlbumField) *AlbumsGetCall {
+	c.Call.Filter(fieldNames(fields))
+	return c
 }
 
-func (c *AlbumsGetCall) Do() (*AlbumsGetResponse, error) {
-	res, err := c.doRequest()
-	if err != nil {
-		return nil, err
-	}
-	debugResponse(res)
-	defer closeBody(res)
-	if err := checkResponse(res); err != nil {
-		return nil, err
-	}
-	albumsRes := &AlbumsServiceResponse{}
-	if err := json.NewDecoder(res.Body).Decode(&albumsRes); err != nil {
-		return nil, err
-	}
-	album := &Album{}
-	if err := json.Unmarshal(*albumsRes.Response.Album, &album); err != nil {
-		return nil, err
-	}
-	exp, err := unmarshallExpansions(album.URIs, albumsRes.Expansions)
-	if err != nil {
-		return nil, err
-	}
-	ret := &AlbumsGetResponse{
-		Album: album,
-		ServerResponse: ServerResponse{
-			Header:         res.Header,
-			HTTPStatusCode: res.StatusCode,
-		},
+func (c *AlbumsGetCall) FilterURIs(names ...string) *AlbumsGetCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *AlbumsGetCall) ExpandTree(expansions ...*Expansion) *AlbumsGetCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *AlbumsGetCall) Context(ctx context.Context) *AlbumsGetCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// AlbumsImagesActionCall posts a list of image URIs to one of the album
+// image actions.
+type AlbumsImagesActionCall struct {
+	*Call[struct{}]
+	imageURIs []string
+	err       error
+}
+
+func newAlbumsImagesAction(s *Service, albumKey, action, param string, imageURIs []string) *AlbumsImagesActionCall {
+	c := &AlbumsImagesActionCall{Call: newCall[struct{}](s, "POST", "album/"+albumKey+action, "", nil), imageURIs: imageURIs}
+	c.body = map[string]string{param: strings.Join(imageURIs, ",")}
+	return c
+}
+
+func (c *AlbumsImagesActionCall) Context(ctx context.Context) *AlbumsImagesActionCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+func (c *AlbumsImagesActionCall) Do() error {
+	if c.err != nil {
+		return c.err
 	}
-	for name, v := range exp {
-		switch name {
-		case "Node":
-			ret.Node = v.(*Node)
-		case "User":
-			ret.User = v.(*User)
-		}
+	if len(c.imageURIs) == 0 {
+		return fmt.Errorf("no image URIs")
 	}
-	return ret, nil
+	_, err := c.Call.Do()
+	return err
 }
 
 type AlbumsGetResponse struct {
 	Album *Album
 
-	// AlbumDownload
+	AlbumDownload []*AlbumDownload
 	// AlbumGeoMedia
 	// AlbumHighlightImage // deprecated
-	// AlbumImages
+	AlbumImages []*Image
 	// AlbumPopularMedia
 	// AlbumPrices
-	// AlbumShareUris
+	AlbumShareUris *AlbumShareURIs
 	// ApplyAlbumTemplate
 	// CollectImages
 	// DeleteAlbumImages
@@ -121,63 +168,69 @@ type AlbumsGetResponse struct {
 	// UploadFromUri
 	User *User
 
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
 	ServerResponse `json:"-"`
 }
 
 type Album struct {
-	AlbumKey            string     `json:",omitempty"`
-	AllowDownloads      bool       `json:",omitempty"`
-	Backprinting        string     `json:",omitempty"`
-	BoutiquePackaging   string     `json:",omitempty"`
-	CanRank             bool       `json:",omitempty"`
-	CanShare            bool       `json:",omitempty"`
-	Clean               bool       `json:",omitempty"`
-	Comments            bool       `json:",omitempty"`
-	Date                *time.Time `json:",omitempty"`
-	Description         string     `json:",omitempty"`
-	EXIF                bool       `json:",omitempty"`
-	External            bool       `json:",omitempty"`
-	FamilyEdit          bool       `json:",omitempty"`
-	Filenames           bool       `json:",omitempty"`
-	FriendEdit          bool       `json:",omitempty"`
-	Geography           bool       `json:",omitempty"`
-	HasDownloadPassword bool       `json:",omitempty"`
-	Header              string     `json:",omitempty"`
-	HideOwner           bool       `json:",omitempty"`
-	ImageCount          int        `json:",omitempty"`
-	ImagesLastUpdated   string     `json:",omitempty"`
-	InterceptShipping   string     `json:",omitempty"`
-	Keywords            string     `json:",omitempty"`
-	LargestSize         string     `json:",omitempty"`
-	LastUpdated         string     `json:",omitempty"`
-	Name                string     `json:",omitempty"`
-	NiceName            string     `json:",omitempty"`
-	NodeID              string     `json:",omitempty"`
-	OriginalSizes       int        `json:",omitempty"`
-	PackagingBranding   bool       `json:",omitempty"`
-	Password            string     `json:",omitempty"`
-	PasswordHint        string     `json:",omitempty"`
-	Printable           bool       `json:",omitempty"`
-	Privacy             string     `json:",omitempty"`
-	ProofDays           int        `json:",omitempty"`
-	Protected           bool       `json:",omitempty"`
-	SecurityType        string     `json:",omitempty"`
-	Share               bool       `json:",omitempty"`
-	SmugSearchable      string     `json:",omitempty"`
-	SortDirection       string     `json:",omitempty"`
-	SortMethod          string     `json:",omitempty"`
-	SquareThumbs        bool       `json:",omitempty"`
-	TemplateURI         string     `json:"TemplateUri"`
-	Title               string     `json:",omitempty"`
-	TotalSizes          int        `json:",omitempty"`
-	URLName             string     `json:"UrlName,omitempty"`
-	URLPath             string     `json:"UrlPath,omitempty"`
-	Watermark           bool       `json:",omitempty"`
-	WorldSearchable     bool       `json:",omitempty"`
+	AlbumKey            string          `json:",omitempty"`
+	AllowDownloads      bool            `json:",omitempty"`
+	Backprinting        string          `json:",omitempty"`
+	BoutiquePackaging   string          `json:",omitempty"`
+	CanRank             bool            `json:",omitempty"`
+	CanShare            bool            `json:",omitempty"`
+	Clean               bool            `json:",omitempty"`
+	Comments            bool            `json:",omitempty"`
+	Date                *time.Time      `json:",omitempty"`
+	Description         string          `json:",omitempty"`
+	EXIF                bool            `json:",omitempty"`
+	External            bool            `json:",omitempty"`
+	FamilyEdit          bool            `json:",omitempty"`
+	Filenames           bool            `json:",omitempty"`
+	FriendEdit          bool            `json:",omitempty"`
+	Geography           bool            `json:",omitempty"`
+	HasDownloadPassword bool            `json:",omitempty"`
+	Header              string          `json:",omitempty"`
+	HideOwner           bool            `json:",omitempty"`
+	ImageCount          int             `json:",omitempty"`
+	ImagesLastUpdated   *Timestamp      `json:",omitempty"`
+	InterceptShipping   string          `json:",omitempty"`
+	Keywords            string          `json:",omitempty"`
+	LargestSize         string          `json:",omitempty"`
+	LastUpdated         *Timestamp      `json:",omitempty"`
+	Name                string          `json:",omitempty"`
+	NiceName            string          `json:",omitempty"`
+	NodeID              string          `json:",omitempty"`
+	OriginalSizes       int             `json:",omitempty"`
+	PackagingBranding   bool            `json:",omitempty"`
+	Password            string          `json:",omitempty"`
+	PasswordHint        string          `json:",omitempty"`
+	Printable           bool            `json:",omitempty"`
+	Privacy             Privacy         `json:",omitempty"`
+	ProofDays           int             `json:",omitempty"`
+	Protected           bool            `json:",omitempty"`
+	SecurityType        SecurityType    `json:",omitempty"`
+	Share               bool            `json:",omitempty"`
+	SmugSearchable      SmugSearchable  `json:",omitempty"`
+	SortDirection       SortDirection   `json:",omitempty"`
+	SortMethod          SortMethod      `json:",omitempty"`
+	SquareThumbs        bool            `json:",omitempty"`
+	TemplateURI         string          `json:"TemplateUri"`
+	Title               string          `json:",omitempty"`
+	TotalSizes          int             `json:",omitempty"`
+	URLName             string          `json:"UrlName,omitempty"`
+	URLPath             string          `json:"UrlPath,omitempty"`
+	Watermark           bool            `json:",omitempty"`
+	WorldSearchable     WorldSearchable `json:",omitempty"`
 
 	ResponseLevel  string `json:",omitempty"`
 	URI            string `json:"Uri,omitempty"`
 	URIDescription string `json:"UriDescription,omitempty"`
 	URIs           *URIs  `json:"Uris,omitempty"`
 	WebURI         string `json:"WebUri,omitempty"`
+
+	Expansions Expansions                 `json:"-"`
+	Extra      map[string]json.RawMessage `json:"-"`
 }
@@ -0,0 +1,53 @@
+package smugmug
+
+import (
+	"testing"
+)
+
+func TestAlbumsGet(t *testing.T) {
+	s := newTestService(t)
+	res, err := s.Albums.Get("kQ3t8P").Expand([]string{"Node", "User"}).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.HTTPStatusCode != 200 {
+		t.Errorf("HTTPStatusCode = %d", res.HTTPStatusCode)
+	}
+	a := res.Album
+	if a.AlbumKey != "kQ3t8P" || a.Name != "Paris" || a.ImageCount != 42 || a.URLPath != "/Travel/Paris" {
+		t.Errorf("Album = %+v", a)
+	}
+	if a.Date == nil || a.Date.Year() != 2015 {
+		t.Errorf("Album.Date = %v", a.Date)
+	}
+	if a.Password != "REDACTED" {
+		t.Errorf("Album.Password was not scrubbed: %q", a.Password)
+	}
+	if res.Node == nil || res.Node.NodeID != a.NodeID || res.Node.Type != "Album" {
+		t.Errorf("Node = %+v", res.Node)
+	}
+	if res.User == nil || res.User.NickName != "cmac" {
+		t.Errorf("User = %+v", res.User)
+	}
+}
+
+func TestAlbumsGetFilter(t *testing.T) {
+	s := newTestService(t)
+	res, err := s.Albums.Get("kQ3t8P").Filter([]string{"AlbumKey", "Name", "ImageCount"}).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Album.Name != "Paris" || res.Album.Description != "" {
+		t.Errorf("Album = %+v", res.Album)
+	}
+	if res.Node != nil || res.User != nil {
+		t.Error("unexpected expansions")
+	}
+}
+
+func TestAlbumsGetNotFound(t *testing.T) {
+	s := newTestService(t)
+	if _, err := s.Albums.Get("nope").Do(); err == nil {
+		t.Fatal("expected error")
+	}
+}
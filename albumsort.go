@@ -0,0 +1,127 @@
+package smugmug
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// SortPosition is where SortImages puts the images it moves: right before
+// or right after a target image of the same album.
+type SortPosition struct {
+	Location MoveLocation
+	Target   string // album image URI, see AlbumImageURI
+}
+
+// SortBefore is the position right before the album image targetURI.
+func SortBefore(targetURI string) SortPosition { return SortPosition{MoveBefore, targetURI} }
+
+// SortAfter is the position right after the album image targetURI.
+func SortAfter(targetURI string) SortPosition { return SortPosition{MoveAfter, targetURI} }
+
+// SortImages moves the given images of albumKey, keeping their order, to
+// position. This is the only way to reorder an album by hand; it switches
+// the album's SortMethod to Position.
+func (r *AlbumsService) SortImages(albumKey string, imageURIs []string, position SortPosition) *AlbumsImagesActionCall {
+	c := newAlbumsImagesAction(r.s, albumKey, "!sortimages", "MoveUris", imageURIs)
+	switch {
+	case !position.Location.Valid():
+		c.err = fmt.Errorf("sort position %q is not Before or After", position.Location)
+	case position.Target == "":
+		c.err = fmt.Errorf("sort position has no target")
+	}
+	body := c.body.(map[string]string)
+	body["MoveLocation"] = string(position.Location)
+	body["Target"] = position.Target
+	return c
+}
+
+// SortableImage is what SortAlbumImages hands its less function. Metadata
+// is nil for images without EXIF data.
+type SortableImage struct {
+	*Image
+	Metadata *ImageMetadata
+}
+
+// SortAlbumImages reorders the album so that less holds between neighbours,
+// keeping the current order of images less considers equal. It moves only
+// the images outside the longest run already in order, batching adjacent
+// ones into one SortImages request, and returns the number of requests made.
+func (r *AlbumsService) SortAlbumImages(ctx context.Context, albumKey string, less func(a, b *SortableImage) bool) (int, error) {
+	var current []*SortableImage
+	err := r.Images(albumKey).Expand([]string{"ImageMetadata"}).Pages(ctx, func(res *AlbumsImagesResponse) error {
+		for _, img := range res.AlbumImages {
+			md, _ := ExpansionOf[*ImageMetadata](img.Expansions, "ImageMetadata")
+			current = append(current, &SortableImage{Image: img, Metadata: md})
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	pos := make(map[*SortableImage]int, len(current))
+	for i, img := range current {
+		pos[img] = i
+	}
+	want := append([]*SortableImage(nil), current...)
+	sort.SliceStable(want, func(i, j int) bool { return less(want[i], want[j]) })
+
+	seq := make([]int, len(want))
+	for i, img := range want {
+		seq[i] = pos[img]
+	}
+	keep := longestIncreasing(seq)
+
+	uri := func(img *SortableImage) string { return AlbumImageURI(albumKey, img.ImageKey) }
+	requests := 0
+	for i := 0; i < len(want); {
+		if keep[i] {
+			i++
+			continue
+		}
+		j := i
+		var uris []string
+		for ; j < len(want) && !keep[j]; j++ {
+			uris = append(uris, uri(want[j]))
+		}
+		var position SortPosition
+		if i > 0 {
+			position = SortAfter(uri(want[i-1]))
+		} else {
+			// The kept run is never empty, so one of its images follows.
+			position = SortBefore(uri(want[j]))
+		}
+		if err := r.SortImages(albumKey, uris, position).Context(ctx).Do(); err != nil {
+			return requests, err
+		}
+		requests++
+		i = j
+	}
+	return requests, nil
+}
+
+// longestIncreasing marks the elements of one longest strictly increasing
+// subsequence of seq.
+func longestIncreasing(seq []int) []bool {
+	var tails []int // tails[k]: index into seq ending the best run of length k+1
+	prev := make([]int, len(seq))
+	for i, v := range seq {
+		k := sort.Search(len(tails), func(k int) bool { return seq[tails[k]] >= v })
+		prev[i] = -1
+		if k > 0 {
+			prev[i] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+	keep := make([]bool, len(seq))
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+			keep[i] = true
+		}
+	}
+	return keep
+}
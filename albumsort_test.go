@@ -0,0 +1,78 @@
+package smugmug_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestSortAlbumImages(t *testing.T) {
+	fake, s, root := newFake(t)
+	a := fake.AddAlbum(root, &smugmug.Album{Name: "Smith Wedding"})
+
+	// Two cameras; the Nikon's clock runs an hour fast.
+	base := time.Date(2026, 6, 20, 14, 0, 0, 0, time.UTC)
+	keys := map[string]string{}
+	for _, shot := range []struct {
+		name, camera string
+		minute       int
+	}{
+		{"a.jpg", "Canon", 0}, {"b.jpg", "Canon", 10}, {"c.jpg", "Nikon", 65},
+		{"d.jpg", "Canon", 20}, {"e.jpg", "Nikon", 90}, {"f.jpg", "Canon", 40},
+	} {
+		img := fake.AddImage(a.AlbumKey, &smugmug.Image{FileName: shot.name}, []byte(shot.name))
+		keys[shot.name] = img.ImageKey
+		fake.SetImageMetadata(img.ImageKey, &smugmug.ImageMetadata{
+			Make:            shot.camera,
+			DateTimeCreated: smugmug.NewTimestamp(base.Add(time.Duration(shot.minute) * time.Minute)),
+		})
+	}
+	fake.AddImage(a.AlbumKey, &smugmug.Image{FileName: "scan.png"}, []byte("scan"))
+
+	ctx := context.Background()
+	uri := func(name string) string { return smugmug.AlbumImageURI(a.AlbumKey, keys[name]) }
+
+	err := s.Albums.SortImages(a.AlbumKey, []string{uri("e.jpg"), uri("c.jpg")}, smugmug.SortBefore(uri("a.jpg"))).Context(ctx).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := albumNames(t, s, a.AlbumKey), "e.jpg, c.jpg, a.jpg, b.jpg, d.jpg, f.jpg, scan.png"; got != want {
+		t.Errorf("after SortImages: %q, want %q", got, want)
+	}
+	if err := s.Albums.SortImages(a.AlbumKey, []string{uri("a.jpg")}, smugmug.SortPosition{Location: "Middle", Target: uri("b.jpg")}).Do(); err == nil {
+		t.Error("bad MoveLocation: err = nil")
+	}
+
+	taken := func(img *smugmug.SortableImage) time.Time {
+		if img.Metadata == nil || img.Metadata.DateTimeCreated == nil {
+			return time.Time{}
+		}
+		t := img.Metadata.DateTimeCreated.Time
+		if img.Metadata.Make == "Nikon" {
+			t = t.Add(-time.Hour)
+		}
+		return t
+	}
+	byTime := func(x, y *smugmug.SortableImage) bool { return taken(x).Before(taken(y)) }
+	n, err := s.Albums.SortAlbumImages(ctx, a.AlbumKey, byTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := albumNames(t, s, a.AlbumKey), "scan.png, a.jpg, c.jpg, b.jpg, d.jpg, e.jpg, f.jpg"; got != want {
+		t.Errorf("after SortAlbumImages: %q, want %q", got, want)
+	}
+	// From "e c a b d f scan": four images stay put, and the other three
+	// go in two runs, e.g. "scan a" before c and e after d.
+	if n != 2 {
+		t.Errorf("SortAlbumImages made %d requests, want 2", n)
+	}
+	if fake.Album(a.AlbumKey).SortMethod != smugmug.SortMethodPosition {
+		t.Errorf("SortMethod = %q", fake.Album(a.AlbumKey).SortMethod)
+	}
+
+	if n, err := s.Albums.SortAlbumImages(ctx, a.AlbumKey, byTime); err != nil || n != 0 {
+		t.Errorf("sorting a sorted album: %d requests, %v", n, err)
+	}
+}
@@ -0,0 +1,107 @@
+package smugmug
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+//go:generate go run ./internal/mockgen -src api.go -o smugmugmock/mocks.go
+
+// AlbumsAPI is the context-based surface of AlbumsService, for callers that
+// want to substitute a fake (see package smugmugmock).
+type AlbumsAPI interface {
+	GetAlbum(ctx context.Context, albumKey string, opts ...CallOption) (*AlbumsGetResponse, error)
+	GetMany(ctx context.Context, albumKeys ...string) (map[string]*Album, error)
+	MoveAlbumImages(ctx context.Context, albumKey string, imageURIs ...string) error
+	CollectAlbumImages(ctx context.Context, albumKey string, imageURIs ...string) error
+	DeleteAlbumImages(ctx context.Context, albumKey string, imageURIs ...string) error
+	ShareLink(ctx context.Context, albumKey, imageKey string) (*ShareLink, error)
+	DownloadAlbum(ctx context.Context, albumKey, dir string, opts *DownloadOptions) ([]string, error)
+	SortAlbumImages(ctx context.Context, albumKey string, less func(a, b *SortableImage) bool) (int, error)
+}
+
+// ImagesAPI is the context-based surface of ImagesService.
+type ImagesAPI interface {
+	GetImage(ctx context.Context, imageKey string, opts ...CallOption) (*ImagesGetResponse, error)
+	GetMany(ctx context.Context, imageKeys ...string) (map[string]*Image, error)
+	PatchImage(ctx context.Context, imageKey string, patch *ImagePatch) (*Image, error)
+	DeleteImage(ctx context.Context, imageKey string) error
+	ImageComments(ctx context.Context, imageKey string) ([]*Comment, error)
+	AddComment(ctx context.Context, imageKey string, comment *Comment) (*Comment, error)
+	RemoveComment(ctx context.Context, commentURI string) error
+}
+
+// NodesAPI is the context-based surface of NodesService.
+type NodesAPI interface {
+	GetNode(ctx context.Context, nodeID string, opts ...CallOption) (*NodesGetResponse, error)
+	GetMany(ctx context.Context, nodeIDs ...string) (map[string]*Node, error)
+	CreateNode(ctx context.Context, parentNodeID string, node *Node, opts ...CallOption) (*Node, error)
+	PatchNode(ctx context.Context, nodeID string, patch *NodePatch) (*Node, error)
+	EnsurePath(ctx context.Context, rootNodeID, path string, leaf NodeType, defaults *Node) (*Node, error)
+	NodeGrants(ctx context.Context, nodeID string) ([]*Grant, error)
+	AddGrant(ctx context.Context, nodeID string, grant *Grant) (*Grant, error)
+	RemoveGrant(ctx context.Context, grantURI string) error
+	Reorder(ctx context.Context, folderID string, orderedChildIDs []string) error
+	SortChildren(ctx context.Context, folderID string, less func(a, b *Node) bool) error
+}
+
+// UsersAPI is the context-based surface of UsersService.
+type UsersAPI interface {
+	GetUser(ctx context.Context, nickname string, opts ...CallOption) (*UsersGetResponse, error)
+	AuthUser(ctx context.Context, opts ...CallOption) (*UsersGetResponse, error)
+	UserGrants(ctx context.Context, nickname string) ([]*Grant, error)
+	UserAlbums(ctx context.Context, nickname string, opts ...CallOption) ([]*Album, error)
+	UserFeaturedAlbums(ctx context.Context, nickname string, opts ...CallOption) ([]*Album, error)
+	UserRecentImages(ctx context.Context, nickname string, limit int, opts ...CallOption) ([]*Image, error)
+	UserPopularMedia(ctx context.Context, nickname string, limit int, opts ...CallOption) ([]*Image, error)
+	UserImageSearch(ctx context.Context, nickname string, q *ImageSearchQuery, limit int, opts ...CallOption) ([]*Image, error)
+	LookupUserPath(ctx context.Context, nickname, urlPath string, opts ...CallOption) (*UsersLookupPathResponse, error)
+}
+
+var (
+	_ AlbumsAPI = (*AlbumsService)(nil)
+	_ ImagesAPI = (*ImagesService)(nil)
+	_ NodesAPI  = (*NodesService)(nil)
+	_ UsersAPI  = (*UsersService)(nil)
+)
+
+// CallOption sets optional query parameters on an API method.
+type CallOption interface {
+	set(params url.Values)
+}
+
+type callOption func(params url.Values)
+
+func (o callOption) set(params url.Values) {
+	o(params)
+}
+
+// Expand requests the named expansions, as the calls' Expand method does.
+func Expand(expansions ...string) CallOption {
+	return callOption(func(params url.Values) {
+		params.Set("_expand", strings.Join(expansions, ","))
+	})
+}
+
+// Filter limits the response to the named fields, as the calls' Filter
+// method does.
+func Filter(fields ...string) CallOption {
+	return callOption(func(params url.Values) {
+		params.Set("_filter", strings.Join(fields, ","))
+	})
+}
+
+// ExpandTree requests multi-level expansions, as the calls' ExpandTree
+// method does.
+func ExpandTree(expansions ...*Expansion) CallOption {
+	return callOption(func(params url.Values) {
+		params.Set("_config", configParam(expansions))
+	})
+}
+
+func applyOptions(params url.Values, opts []CallOption) {
+	for _, opt := range opts {
+		opt.set(params)
+	}
+}
@@ -0,0 +1,46 @@
+package smugmug_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestContextAPI(t *testing.T) {
+	fake, s, root := newFake(t)
+	a := fake.AddAlbum(root, &smugmug.Album{Name: "Paris"})
+
+	var albums smugmug.AlbumsAPI = s.Albums
+	res, err := albums.GetAlbum(context.Background(), a.AlbumKey, smugmug.Expand("Node"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Album.Name != "Paris" || res.Node == nil || res.Node.NodeID != a.NodeID {
+		t.Errorf("GetAlbum = %+v", res)
+	}
+
+	var nodes smugmug.NodesAPI = s.Nodes
+	n, err := nodes.CreateNode(context.Background(), root, &smugmug.Node{Name: "Clients", Type: "Folder"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.URLName != "Clients" {
+		t.Errorf("CreateNode = %+v", n)
+	}
+
+	var users smugmug.UsersAPI = s.Users
+	ures, err := users.AuthUser(context.Background(), smugmug.Expand("Node"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ures.Node == nil || ures.Node.NodeID != root {
+		t.Errorf("AuthUser = %+v", ures)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.Images.GetImage(ctx, "missing"); err == nil {
+		t.Error("expected error from canceled context")
+	}
+}
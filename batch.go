@@ -0,0 +1,130 @@
+package smugmug
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// maxURLLength bounds the request URL of a batch call; longer key lists
+	// are split into several requests.
+	maxURLLength = 2000
+
+	maxConcurrentBatches = 4
+)
+
+// BatchErrors is returned by the GetMany calls when some of the requested
+// keys could not be fetched. The objects that were fetched are still
+// returned.
+type BatchErrors map[string]error
+
+func (e BatchErrors) Error() string {
+	keys := make([]string, 0, len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	msgs := make([]string, len(keys))
+	for i, k := range keys {
+		msgs[i] = k + ": " + e[k].Error()
+	}
+	return fmt.Sprintf("smugmug: %d of the requested objects failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// getMany fetches the objects at kind/<key> for every key using _multiuri
+// and returns them keyed by the requested key. id reports the key of a
+// returned object.
+func getMany[T any](ctx context.Context, s *Service, kind string, keys []string, id func(*T) string, opts ...CallOption) (map[string]*T, error) {
+	ret := map[string]*T{}
+	errs := BatchErrors{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentBatches)
+	for _, chunk := range chunkKeys(s.BasePath+kind+"/", kind, keys) {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(chunk []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			uris := make([]string, len(chunk))
+			for i, key := range chunk {
+				uris[i] = multiURI(s.BasePath, kind, key)
+			}
+			var objs []*T
+			// A fresh slice per chunk: appending to opts could share its
+			// backing array between goroutines.
+			chunkOpts := append(append([]CallOption(nil), opts...), callOption(func(params url.Values) {
+				params.Set("_multiuri", strings.Join(uris, ","))
+			}))
+			err := s.Get(ctx, kind+"/", &objs, chunkOpts...)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				for _, key := range chunk {
+					errs[key] = err
+				}
+				return
+			}
+			found := map[string]*T{}
+			for _, obj := range objs {
+				if obj != nil {
+					found[id(obj)] = obj
+				}
+			}
+			for _, key := range chunk {
+				if obj, ok := found[trimSerial(key)]; ok {
+					ret[key] = obj
+				} else {
+					errs[key] = fmt.Errorf("%s not found", kind)
+				}
+			}
+		}(chunk)
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return ret, errs
+	}
+	return ret, nil
+}
+
+// chunkKeys splits keys into groups whose _multiuri fits in maxURLLength.
+func chunkKeys(base, kind string, keys []string) [][]string {
+	const params = "?_multiuri=&_expand=&_shorturis=&_verbosity=1"
+	var chunks [][]string
+	var chunk []string
+	size := len(base) + len(params)
+	for _, key := range keys {
+		n := len(url.QueryEscape(multiURI(base, kind, key))) + 1
+		if len(chunk) > 0 && size+n > maxURLLength {
+			chunks = append(chunks, chunk)
+			chunk = nil
+			size = len(base) + len(params)
+		}
+		chunk = append(chunk, key)
+		size += n
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+func multiURI(base, kind, key string) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "/api/v2/" + kind + "/" + key
+	}
+	return u.Path + kind + "/" + key
+}
+
+// trimSerial strips the "-N" serial SmugMug appends to image keys in URIs.
+func trimSerial(key string) string {
+	if i := strings.LastIndex(key, "-"); i > 0 && i < len(key)-1 && strings.Trim(key[i+1:], "0123456789") == "" {
+		return key[:i]
+	}
+	return key
+}
@@ -0,0 +1,52 @@
+package smugmug_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestImagesGetMany(t *testing.T) {
+	fake, s, root := newFake(t)
+	a := fake.AddAlbum(root, &smugmug.Album{Name: "Paris"})
+	var keys []string
+	for i := 0; i < 250; i++ {
+		img := fake.AddImage(a.AlbumKey, &smugmug.Image{FileName: fmt.Sprintf("%03d.jpg", i)}, nil)
+		keys = append(keys, img.ImageKey+"-0")
+	}
+	keys = append(keys, "missing-0")
+
+	images, err := s.Images.GetMany(context.Background(), keys...)
+	errs, ok := err.(smugmug.BatchErrors)
+	if !ok || len(errs) != 1 || errs["missing-0"] == nil {
+		t.Fatalf("err = %v", err)
+	}
+	if len(images) != 250 {
+		t.Fatalf("got %d images, want 250", len(images))
+	}
+	if img := images[keys[42]]; img == nil || img.FileName != "042.jpg" {
+		t.Errorf("images[%s] = %+v", keys[42], img)
+	}
+}
+
+func TestNodesGetMany(t *testing.T) {
+	fake, s, root := newFake(t)
+	a := fake.AddAlbum(root, &smugmug.Album{Name: "Paris"})
+
+	nodes, err := s.Nodes.GetMany(context.Background(), root, a.NodeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 2 || nodes[a.NodeID].Type != "Album" || !nodes[root].IsRoot {
+		t.Errorf("nodes = %+v", nodes)
+	}
+	albums, err := s.Albums.GetMany(context.Background(), a.AlbumKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if albums[a.AlbumKey].Name != "Paris" {
+		t.Errorf("albums = %+v", albums)
+	}
+}
@@ -0,0 +1,275 @@
+package smugmug
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Call is the request core behind every typed call. T is the response type
+// returned by Do; payload picks the field of a *T that receives
+// Response.<locator>, and is nil for calls whose response carries nothing.
+// An empty locator is read from the response and stored in T's Locator
+// field before payload is called. The exported call types embed a *Call and
+// only add methods that return themselves, so chained calls keep their type.
+type Call[T any] struct {
+	s         *Service
+	method    string
+	path      string // relative to BasePath; a leading "!" is appended to it
+	locator   string
+	payload   func(*T) interface{}
+	body      interface{}
+	urlParams url.Values
+	ctx       context.Context
+}
+
+func newCall[T any](s *Service, method, path, locator string, payload func(*T) interface{}) *Call[T] {
+	return &Call[T]{
+		s:         s,
+		method:    method,
+		path:      path,
+		locator:   locator,
+		payload:   payload,
+		urlParams: url.Values{},
+	}
+}
+
+func (c *Call[T]) Expand(expansions []string) *Call[T] {
+	c.urlParams.Set("_expand", strings.Join(expansions, ","))
+	return c
+}
+
+func (c *Call[T]) Filter(filter []string) *Call[T] {
+	c.urlParams.Set("_filter", strings.Join(filter, ","))
+	return c
+}
+
+func (c *Call[T]) FilterURIs(names ...string) *Call[T] {
+	c.urlParams.Set("_filteruri", strings.Join(names, ","))
+	return c
+}
+
+func (c *Call[T]) ExpandTree(expansions ...*Expansion) *Call[T] {
+	c.urlParams.Set("_config", configParam(expansions))
+	return c
+}
+
+func (c *Call[T]) Context(ctx context.Context) *Call[T] {
+	c.ctx = ctx
+	return c
+}
+
+func (c *Call[T]) url() string {
+	if strings.HasPrefix(c.path, "!") {
+		return strings.TrimRight(c.s.BasePath, "/") + c.path
+	}
+	return resolveRelative(c.s.BasePath, c.path)
+}
+
+func (c *Call[T]) doRequest() (*http.Response, error) {
+	urls := c.url() + "?" + encodeURLParams(c.urlParams)
+	var body io.Reader
+	if c.body != nil {
+		data, err := json.Marshal(c.body)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, _ := http.NewRequest(c.method, urls, body)
+	if c.ctx != nil {
+		req = req.WithContext(c.ctx)
+	}
+	c.s.setHeaders(req)
+	debugRequest(req)
+	return c.s.client.Do(req)
+}
+
+// Do sends the request and decodes the response. Besides the payload it
+// fills the Pages, Requested, ServerResponse and Other fields of T when T
+// has them, and attaches expansions to the returned objects.
+func (c *Call[T]) Do() (*T, error) {
+	res, err := c.doRequest()
+	if err != nil {
+		return nil, err
+	}
+	debugResponse(res)
+	defer closeBody(res)
+	if err := checkResponse(res); err != nil {
+		return nil, err
+	}
+	envelope := &uriResponse{}
+	if err := json.NewDecoder(res.Body).Decode(envelope); err != nil {
+		return nil, err
+	}
+	ret := new(T)
+	setField(ret, "Requested", requestedFields(c.urlParams))
+	setField(ret, "ServerResponse", ServerResponse{
+		Header:         res.Header,
+		HTTPStatusCode: res.StatusCode,
+	})
+	if c.payload == nil {
+		return ret, nil
+	}
+	locator := c.locator
+	if locator == "" {
+		// Calls that can resolve to several kinds of object take the
+		// locator the response names.
+		if err := json.Unmarshal(envelope.Response["Locator"], &locator); err != nil || locator == "" {
+			return nil, fmt.Errorf("smugmug: response from %s names no Locator", c.path)
+		}
+		setField(ret, "Locator", locator)
+	}
+	dst := c.payload(ret)
+	if dst == nil {
+		return nil, fmt.Errorf("smugmug: unexpected %q in response from %s", locator, c.path)
+	}
+	if raw, ok := envelope.Response[locator]; ok {
+		if err := json.Unmarshal(raw, dst); err != nil {
+			return nil, err
+		}
+	} else if locatorType(envelope) != "Objects" {
+		// Empty collections omit the locator entirely; objects never do.
+		return nil, fmt.Errorf("smugmug: no %q in response from %s", locator, c.path)
+	}
+	if raw, ok := envelope.Response["Pages"]; ok {
+		var pages *Pages
+		if err := json.Unmarshal(raw, &pages); err != nil {
+			return nil, err
+		}
+		setField(ret, "Pages", pages)
+	}
+
+	v := reflect.ValueOf(dst).Elem().Interface()
+	if obj, ok := v.(expandable); ok {
+		if !reflect.ValueOf(obj).IsNil() {
+			other, err := decodeExpansions(ret, obj, envelope.Expansions)
+			if err != nil {
+				return nil, err
+			}
+			setField(ret, "Other", other)
+		}
+	} else {
+		for _, obj := range expandables(v) {
+			nested, err := unmarshallExpansions(obj.uris(), envelope.Expansions)
+			if err != nil {
+				return nil, err
+			}
+			if len(nested) > 0 {
+				obj.setExpansions(nested)
+			}
+		}
+	}
+	if err := c.s.checkStrict(ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Pages calls f for every page of results, starting at the current start
+// parameter, until f returns an error or there are no more pages. Calls
+// without paging deliver a single page.
+func (c *Call[T]) Pages(ctx context.Context, f func(*T) error) error {
+	c.ctx = ctx
+	start, ok := c.urlParams["start"]
+	defer func() {
+		if ok {
+			c.urlParams["start"] = start
+		} else {
+			c.urlParams.Del("start")
+		}
+	}()
+	for {
+		x, err := c.Do()
+		if err != nil {
+			return err
+		}
+		if err := f(x); err != nil {
+			return err
+		}
+		p, _ := field(x, "Pages").(*Pages)
+		if p == nil || p.NextPage == "" || p.Count <= 0 {
+			// An empty page would only be requested again.
+			return nil
+		}
+		c.urlParams.Set("start", strconv.Itoa(p.Start+p.Count))
+	}
+}
+
+// allPages fetches every page of c and returns the items of all of them,
+// read from the slice c's payload points to.
+func allPages[E any, T any](ctx context.Context, c *Call[T]) ([]E, error) {
+	var ret []E
+	err := c.Pages(ctx, func(x *T) error {
+		items, ok := c.payload(x).(*[]E)
+		if !ok {
+			return fmt.Errorf("smugmug: %s does not list %T", c.path, ret)
+		}
+		ret = append(ret, *items...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// firstItems is allPages stopped once limit items are in, or every page when
+// limit is not positive.
+func firstItems[E any, T any](ctx context.Context, c *Call[T], limit int) ([]E, error) {
+	if limit <= 0 {
+		return allPages[E](ctx, c)
+	}
+	if c.urlParams.Get("count") == "" {
+		c.urlParams.Set("count", strconv.Itoa(limit))
+	}
+	var ret []E
+	err := c.Pages(ctx, func(x *T) error {
+		items, ok := c.payload(x).(*[]E)
+		if !ok {
+			return fmt.Errorf("smugmug: %s does not list %T", c.path, ret)
+		}
+		ret = append(ret, *items...)
+		if len(ret) >= limit {
+			return errStop
+		}
+		return nil
+	})
+	if err != nil && err != errStop {
+		return nil, err
+	}
+	if len(ret) > limit {
+		ret = ret[:limit]
+	}
+	return ret, nil
+}
+
+func locatorType(env *uriResponse) string {
+	var s string
+	json.Unmarshal(env.Response["LocatorType"], &s)
+	return s
+}
+
+// setField assigns value to the named field of the struct dst points to,
+// if it has one of a compatible type.
+func setField(dst interface{}, name string, value interface{}) {
+	f := reflect.ValueOf(dst).Elem().FieldByName(name)
+	if f.IsValid() && f.CanSet() && reflect.TypeOf(value).AssignableTo(f.Type()) {
+		f.Set(reflect.ValueOf(value))
+	}
+}
+
+func field(v interface{}, name string) interface{} {
+	f := reflect.ValueOf(v).Elem().FieldByName(name)
+	if !f.IsValid() {
+		return nil
+	}
+	return f.Interface()
+}
@@ -0,0 +1,79 @@
+package smugmug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+func (r *ImagesService) PostComment(imageKey string, comment *Comment) *ImagesPostCommentCall {
+	c := &ImagesPostCommentCall{newCall(r.s, "POST", "image/"+imageKey+"!comments", "Comment", func(c *Comment) interface{} { return c })}
+	c.body = comment
+	return c
+}
+
+// DeleteComment deletes the comment at commentURI, as found in Comment.URI.
+func (r *ImagesService) DeleteComment(commentURI string) *ImagesDeleteCommentCall {
+	return &ImagesDeleteCommentCall{newCall[struct{}](r.s, "DELETE", commentURI, "", nil)}
+}
+
+// ImageComments returns all the comments on the image.
+func (r *ImagesService) ImageComments(ctx context.Context, imageKey string) ([]*Comment, error) {
+	return allPages[*Comment](ctx, r.Comments(imageKey).Call)
+}
+
+func (r *ImagesService) AddComment(ctx context.Context, imageKey string, comment *Comment) (*Comment, error) {
+	return r.PostComment(imageKey, comment).Context(ctx).Do()
+}
+
+func (r *ImagesService) RemoveComment(ctx context.Context, commentURI string) error {
+	return r.DeleteComment(commentURI).Context(ctx).Do()
+}
+
+type ImagesPostCommentCall struct {
+	*Call[Comment]
+}
+
+func (c *ImagesPostCommentCall) Context(ctx context.Context) *ImagesPostCommentCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+func (c *ImagesPostCommentCall) Do() (*Comment, error) {
+	if comment := c.body.(*Comment); comment == nil {
+		return nil, fmt.Errorf("comment is nil")
+	} else if comment.Text == "" {
+		return nil, fmt.Errorf("comment Text is empty")
+	}
+	return c.Call.Do()
+}
+
+type ImagesDeleteCommentCall struct {
+	*Call[struct{}]
+}
+
+func (c *ImagesDeleteCommentCall) Context(ctx context.Context) *ImagesDeleteCommentCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+func (c *ImagesDeleteCommentCall) Do() error {
+	_, err := c.Call.Do()
+	return err
+}
+
+type Comment struct {
+	Date   *time.Time `json:",omitempty"`
+	Name   string     `json:",omitempty"` // display name of the author
+	Rating int        `json:",omitempty"` // 0 (none) to 5
+	Text   string     `json:",omitempty"`
+
+	ResponseLevel string `json:",omitempty"`
+	URI           string `json:"Uri,omitempty"`
+	URIs          *URIs  `json:"Uris,omitempty"`
+	WebURI        string `json:"WebUri,omitempty"`
+
+	Expansions Expansions                 `json:"-"`
+	Extra      map[string]json.RawMessage `json:"-"`
+}
@@ -0,0 +1,58 @@
+package smugmug_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestComments(t *testing.T) {
+	fake, s, root := newFake(t)
+	a := fake.AddAlbum(root, &smugmug.Album{Name: "Proofs"})
+	img := fake.AddImage(a.AlbumKey, &smugmug.Image{FileName: "a.jpg"}, []byte("a"))
+	for n := 1; n <= 3; n++ {
+		fake.AddComment(img.ImageKey, &smugmug.Comment{Name: "Client", Text: fmt.Sprintf("note %d", n)})
+	}
+
+	ctx := context.Background()
+
+	page, err := s.Images.Comments(img.ImageKey).Count(2).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.ImageComments) != 2 || page.Pages.Total != 3 || page.Pages.NextPage == "" {
+		t.Errorf("first page = %+v", page)
+	}
+
+	c, err := s.Images.AddComment(ctx, img.ImageKey, &smugmug.Comment{Text: "Love this one", Rating: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.URI == "" || c.Date == nil || c.Rating != 5 {
+		t.Errorf("AddComment = %+v", c)
+	}
+	if _, err := s.Images.AddComment(ctx, img.ImageKey, &smugmug.Comment{Rating: 3}); err == nil {
+		t.Error("expected error for empty Text")
+	}
+
+	all, err := s.Images.ImageComments(ctx, img.ImageKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 4 || all[0].Text != "note 1" || all[3].Text != "Love this one" {
+		t.Errorf("ImageComments = %+v", all)
+	}
+
+	if err := s.Images.RemoveComment(ctx, all[0].URI); err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.Images.Get(img.ImageKey).Expand([]string{"ImageComments"}).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.ImageComments) != 3 || res.ImageComments[0].Text != "note 2" {
+		t.Errorf("ImageComments expansion = %+v", res.ImageComments)
+	}
+}
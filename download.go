@@ -0,0 +1,199 @@
+package smugmug
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Statuses of an AlbumDownload part.
+const (
+	DownloadStatusProcessing = "Processing"
+	DownloadStatusReady      = "Ready"
+	DownloadStatusFailed     = "Failed"
+)
+
+// AlbumDownload is one part of a zip archive of an album; large albums are
+// split into several parts. URL and Size are set once Status is Ready.
+type AlbumDownload struct {
+	FileName string `json:",omitempty"`
+	Size     int64  `json:",omitempty"`
+	Status   string `json:",omitempty"`
+	URL      string `json:"Url,omitempty"`
+
+	URI            string `json:"Uri,omitempty"`
+	URIDescription string `json:"UriDescription,omitempty"`
+}
+
+// RequestDownload asks SmugMug to build a zip archive of the album. password
+// is the album's download password, or "" if it has none. Poll the parts
+// with Download.
+func (r *AlbumsService) RequestDownload(albumKey, password string) *AlbumsRequestDownloadCall {
+	c := &AlbumsRequestDownloadCall{newCall(r.s, "POST", "album/"+albumKey+"!download", "Download", func(res *AlbumsDownloadResponse) interface{} { return &res.AlbumDownload })}
+	body := map[string]string{}
+	if password != "" {
+		body["Password"] = password
+	}
+	c.body = body
+	return c
+}
+
+type AlbumsRequestDownloadCall struct {
+	*Call[AlbumsDownloadResponse]
+}
+
+func (c *AlbumsRequestDownloadCall) Context(ctx context.Context) *AlbumsRequestDownloadCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// DownloadOptions configures DownloadAlbum.
+type DownloadOptions struct {
+	Password     string        // download password, for albums with HasDownloadPassword
+	PollInterval time.Duration // wait between status checks; 5s if zero
+	MaxWait      time.Duration // give up if the parts aren't ready by then; 30m if zero
+}
+
+// DownloadAlbum requests a zip archive of the album, waits until every part
+// is ready and writes the parts into dir, returning their paths. An empty
+// album has no parts and returns no paths. Each part is written to a
+// temporary file and only renamed into place once its size matches the one
+// SmugMug reported. Albums that don't allow downloads, or need a download
+// password that wasn't given, fail before anything is requested, and part
+// file names that aren't plain, distinct names fail before anything is
+// written.
+func (r *AlbumsService) DownloadAlbum(ctx context.Context, albumKey, dir string, opts *DownloadOptions) ([]string, error) {
+	if opts == nil {
+		opts = &DownloadOptions{}
+	}
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	maxWait := opts.MaxWait
+	if maxWait <= 0 {
+		maxWait = 30 * time.Minute
+	}
+	res, err := r.GetAlbum(ctx, albumKey)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Album.AllowDownloads {
+		return nil, fmt.Errorf("album %s does not allow downloads", albumKey)
+	}
+	if res.Album.HasDownloadPassword && opts.Password == "" {
+		return nil, fmt.Errorf("album %s needs a download password", albumKey)
+	}
+	requested, err := r.RequestDownload(albumKey, opts.Password).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	// Until the archive is queued the status may list no parts; only an
+	// album without images really has none.
+	empty := res.Album.ImageCount == 0 && len(requested.AlbumDownload) == 0
+
+	var parts []*AlbumDownload
+	deadline := time.Now().Add(maxWait)
+	for {
+		res, err := r.Download(albumKey).Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		parts = res.AlbumDownload
+		if ready, err := downloadsReady(parts, empty); err != nil {
+			return nil, err
+		} else if ready {
+			break
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("album %s: download not ready after %v", albumKey, maxWait)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, part := range parts {
+		name := part.FileName
+		if name == "" || name == "." || name == ".." || name != filepath.Base(name) {
+			return nil, fmt.Errorf("album %s: bad download file name %q", albumKey, name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("album %s: download file name %q used twice", albumKey, name)
+		}
+		seen[name] = true
+	}
+
+	var paths []string
+	for _, part := range parts {
+		path := filepath.Join(dir, part.FileName)
+		if err := r.s.fetchFile(ctx, part.URL, path, part.Size); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// downloadsReady reports whether every part is ready. No parts at all
+// counts as ready only when empty is set.
+func downloadsReady(parts []*AlbumDownload, empty bool) (bool, error) {
+	if len(parts) == 0 {
+		return empty, nil
+	}
+	for _, p := range parts {
+		switch p.Status {
+		case DownloadStatusReady:
+		case DownloadStatusFailed:
+			return false, fmt.Errorf("building %s failed", p.FileName)
+		default:
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// fetchFile streams rawURL into path, checking that size bytes arrive.
+func (s *Service) fetchFile(ctx context.Context, rawURL, path string, size int64) error {
+	req, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", s.userAgent())
+	debugRequest(req)
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	// The body is not dumped: it is binary and may be large.
+	defer closeBody(res)
+	if err := checkResponse(res); err != nil {
+		return err
+	}
+
+	tmp := path + ".part"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(f, res.Body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && n != size {
+		err = fmt.Errorf("%s: got %d bytes, want %d", filepath.Base(path), n, size)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,102 @@
+package smugmug_test
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestDownloadAlbum(t *testing.T) {
+	fake, s, root := newFake(t)
+	a := fake.AddAlbum(root, &smugmug.Album{Name: "Smith Wedding", AllowDownloads: true})
+	for _, name := range []string{"vows.jpg", "cake.jpg", "dance.jpg"} {
+		fake.AddImage(a.AlbumKey, &smugmug.Image{FileName: name}, []byte(name))
+	}
+	fake.SetDownloadPassword(a.AlbumKey, "cake")
+	fake.SetZipPartSize(2)
+	closed := fake.AddAlbum(root, &smugmug.Album{Name: "Proofs"})
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	opts := &smugmug.DownloadOptions{PollInterval: time.Millisecond}
+
+	if _, err := s.Albums.DownloadAlbum(ctx, closed.AlbumKey, dir, opts); err == nil {
+		t.Error("album without AllowDownloads: err = nil")
+	}
+	if _, err := s.Albums.DownloadAlbum(ctx, a.AlbumKey, dir, opts); err == nil {
+		t.Error("missing download password: err = nil")
+	}
+	opts.Password = "pie"
+	if _, err := s.Albums.DownloadAlbum(ctx, a.AlbumKey, dir, opts); err == nil {
+		t.Error("wrong download password: err = nil")
+	}
+
+	opts.Password = "cake"
+	paths, err := s.Albums.DownloadAlbum(ctx, a.AlbumKey, dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("paths = %v, want 2 parts", paths)
+	}
+	var files []*zip.File
+	for _, p := range paths {
+		zr, err := zip.OpenReader(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, zr.File...)
+		zr.Close()
+	}
+	if got := names(files); got != "vows.jpg, cake.jpg, dance.jpg" {
+		t.Errorf("archived %q", got)
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(dir, "*.part")); len(leftovers) != 0 {
+		t.Errorf("temporary files left: %v", leftovers)
+	}
+	if _, err := os.Stat(paths[0]); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDownloadAlbumEdgeCases(t *testing.T) {
+	fake, s, root := newFake(t)
+	empty := fake.AddAlbum(root, &smugmug.Album{Name: "Empty", AllowDownloads: true})
+	escape := fake.AddAlbum(root, &smugmug.Album{Name: "Escape", URLName: "../Escape", AllowDownloads: true})
+	fake.AddImage(escape.AlbumKey, &smugmug.Image{FileName: "a.jpg"}, []byte("a"))
+	slow := fake.AddAlbum(root, &smugmug.Album{Name: "Slow", AllowDownloads: true})
+	fake.AddImage(slow.AlbumKey, &smugmug.Image{FileName: "b.jpg"}, []byte("b"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	dir := filepath.Join(t.TempDir(), "out")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	opts := &smugmug.DownloadOptions{PollInterval: time.Millisecond}
+
+	if paths, err := s.Albums.DownloadAlbum(ctx, empty.AlbumKey, dir, opts); err != nil || len(paths) != 0 {
+		t.Errorf("empty album: paths %v, err %v", paths, err)
+	}
+	if _, err := s.Albums.DownloadAlbum(ctx, escape.AlbumKey, dir, opts); err == nil {
+		t.Error("file name outside dir: err = nil")
+	}
+	if written, _ := filepath.Glob(filepath.Join(filepath.Dir(dir), "*")); len(written) != 1 {
+		t.Errorf("wrote outside dir: %v", written)
+	}
+	queued := fake.AddAlbum(root, &smugmug.Album{Name: "Queued", AllowDownloads: true})
+	fake.AddImage(queued.AlbumKey, &smugmug.Image{FileName: "c.jpg"}, []byte("c"))
+	fake.SetZipListDelay(2)
+	if paths, err := s.Albums.DownloadAlbum(ctx, queued.AlbumKey, dir, opts); err != nil || len(paths) != 1 {
+		t.Errorf("parts listed late: paths %v, err %v", paths, err)
+	}
+	opts.MaxWait = time.Nanosecond
+	if _, err := s.Albums.DownloadAlbum(ctx, slow.AlbumKey, dir, opts); err == nil || ctx.Err() != nil {
+		t.Errorf("MaxWait: err = %v", err)
+	}
+}
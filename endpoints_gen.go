@@ -0,0 +1,1044 @@
+// Code generated by internal/endpointgen; DO NOT EDIT.
+
+package smugmug
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func init() {
+	expansionDecoders["AlbumDownload"] = decodeObjects[AlbumDownload]("Download")
+	expansionDecoders["AlbumImages"] = decodeObjects[Image]("AlbumImage")
+	expansionDecoders["AlbumShareUris"] = decodeObject[AlbumShareURIs]("AlbumShareUris")
+	expansionDecoders["ImageComments"] = decodeObjects[Comment]("Comment")
+	expansionDecoders["ImageDownload"] = decodeObject[ImageDownload]("ImageDownload")
+	expansionDecoders["LargestImage"] = decodeObject[LargestImage]("LargestImage")
+	expansionDecoders["ImageMetadata"] = decodeObject[ImageMetadata]("ImageMetadata")
+	expansionDecoders["ImageSizeDetails"] = decodeObject[ImageSizeDetails]("ImageSizeDetails")
+	expansionDecoders["ImageSizes"] = decodeObject[ImageSizes]("ImageSizes")
+	expansionDecoders["ChildNodes"] = decodeObjects[Node]("Node")
+	expansionDecoders["NodeGrants"] = decodeObjects[Grant]("Grant")
+	expansionDecoders["ParentNodes"] = decodeObjects[Node]("Node")
+	expansionDecoders["UserAlbums"] = decodeObjects[Album]("Album")
+	expansionDecoders["UserFeaturedAlbums"] = decodeObjects[Album]("Album")
+	expansionDecoders["UserGrants"] = decodeObjects[Grant]("Grant")
+	expansionDecoders["UserImageSearch"] = decodeObjects[Image]("Image")
+	expansionDecoders["UserPopularMedia"] = decodeObjects[Image]("Image")
+	expansionDecoders["UserRecentImages"] = decodeObjects[Image]("Image")
+}
+
+// Download returns a call for the AlbumDownload endpoint, described in endpoints/album_download.json.
+func (r *AlbumsService) Download(albumKey string) *AlbumsDownloadCall {
+	return &AlbumsDownloadCall{newCall(r.s, "GET", "album/"+albumKey+"!download", "Download", func(res *AlbumsDownloadResponse) interface{} { return &res.AlbumDownload })}
+}
+
+type AlbumsDownloadCall struct {
+	*Call[AlbumsDownloadResponse]
+}
+
+type AlbumsDownloadResponse struct {
+	AlbumDownload []*AlbumDownload
+	Pages         *Pages
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+func (c *AlbumsDownloadCall) Expand(expansions []string) *AlbumsDownloadCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *AlbumsDownloadCall) Filter(filter []string) *AlbumsDownloadCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *AlbumsDownloadCall) FilterURIs(names ...string) *AlbumsDownloadCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *AlbumsDownloadCall) ExpandTree(expansions ...*Expansion) *AlbumsDownloadCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *AlbumsDownloadCall) Context(ctx context.Context) *AlbumsDownloadCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// Images returns a call for the AlbumImages endpoint, described in endpoints/album_images.json.
+func (r *AlbumsService) Images(albumKey string) *AlbumsImagesCall {
+	return &AlbumsImagesCall{newCall(r.s, "GET", "album/"+albumKey+"!images", "AlbumImage", func(res *AlbumsImagesResponse) interface{} { return &res.AlbumImages })}
+}
+
+type AlbumsImagesCall struct {
+	*Call[AlbumsImagesResponse]
+}
+
+type AlbumsImagesResponse struct {
+	AlbumImages []*Image
+	Pages       *Pages
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+// Count sets the "count" parameter. Number of objects to return per page.
+func (c *AlbumsImagesCall) Count(v int) *AlbumsImagesCall {
+	c.urlParams.Set("count", strconv.Itoa(v))
+	return c
+}
+
+// Start sets the "start" parameter. Index of the first object to return.
+func (c *AlbumsImagesCall) Start(v int) *AlbumsImagesCall {
+	c.urlParams.Set("start", strconv.Itoa(v))
+	return c
+}
+
+func (c *AlbumsImagesCall) Expand(expansions []string) *AlbumsImagesCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *AlbumsImagesCall) Filter(filter []string) *AlbumsImagesCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *AlbumsImagesCall) Fields(fields ...ImageField) *AlbumsImagesCall {
+	c.Call.Filter(fieldNames(fields))
+	return c
+}
+
+func (c *AlbumsImagesCall) FilterURIs(names ...string) *AlbumsImagesCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *AlbumsImagesCall) ExpandTree(expansions ...*Expansion) *AlbumsImagesCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *AlbumsImagesCall) Context(ctx context.Context) *AlbumsImagesCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// ShareURIs returns a call for the AlbumShareUris endpoint, described in endpoints/album_shareuris.json.
+func (r *AlbumsService) ShareURIs(albumKey string) *AlbumsShareURIsCall {
+	return &AlbumsShareURIsCall{newCall(r.s, "GET", "album/"+albumKey+"!shareuris", "AlbumShareUris", func(res *AlbumsShareURIsResponse) interface{} { return &res.AlbumShareUris })}
+}
+
+type AlbumsShareURIsCall struct {
+	*Call[AlbumsShareURIsResponse]
+}
+
+type AlbumsShareURIsResponse struct {
+	AlbumShareUris *AlbumShareURIs
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+func (c *AlbumsShareURIsCall) Expand(expansions []string) *AlbumsShareURIsCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *AlbumsShareURIsCall) Filter(filter []string) *AlbumsShareURIsCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *AlbumsShareURIsCall) FilterURIs(names ...string) *AlbumsShareURIsCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *AlbumsShareURIsCall) ExpandTree(expansions ...*Expansion) *AlbumsShareURIsCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *AlbumsShareURIsCall) Context(ctx context.Context) *AlbumsShareURIsCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// Comments returns a call for the ImageComments endpoint, described in endpoints/image_comments.json.
+func (r *ImagesService) Comments(imageKey string) *ImagesCommentsCall {
+	return &ImagesCommentsCall{newCall(r.s, "GET", "image/"+imageKey+"!comments", "Comment", func(res *ImagesCommentsResponse) interface{} { return &res.ImageComments })}
+}
+
+type ImagesCommentsCall struct {
+	*Call[ImagesCommentsResponse]
+}
+
+type ImagesCommentsResponse struct {
+	ImageComments []*Comment
+	Pages         *Pages
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+// Count sets the "count" parameter. Number of objects to return per page.
+func (c *ImagesCommentsCall) Count(v int) *ImagesCommentsCall {
+	c.urlParams.Set("count", strconv.Itoa(v))
+	return c
+}
+
+// Start sets the "start" parameter. Index of the first object to return.
+func (c *ImagesCommentsCall) Start(v int) *ImagesCommentsCall {
+	c.urlParams.Set("start", strconv.Itoa(v))
+	return c
+}
+
+func (c *ImagesCommentsCall) Expand(expansions []string) *ImagesCommentsCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *ImagesCommentsCall) Filter(filter []string) *ImagesCommentsCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *ImagesCommentsCall) FilterURIs(names ...string) *ImagesCommentsCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *ImagesCommentsCall) ExpandTree(expansions ...*Expansion) *ImagesCommentsCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *ImagesCommentsCall) Context(ctx context.Context) *ImagesCommentsCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// Download returns a call for the ImageDownload endpoint, described in endpoints/image_download.json.
+func (r *ImagesService) Download(imageKey string) *ImagesDownloadCall {
+	return &ImagesDownloadCall{newCall(r.s, "GET", "image/"+imageKey+"!download", "ImageDownload", func(res *ImagesDownloadResponse) interface{} { return &res.ImageDownload })}
+}
+
+type ImagesDownloadCall struct {
+	*Call[ImagesDownloadResponse]
+}
+
+type ImagesDownloadResponse struct {
+	ImageDownload *ImageDownload
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+func (c *ImagesDownloadCall) Expand(expansions []string) *ImagesDownloadCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *ImagesDownloadCall) Filter(filter []string) *ImagesDownloadCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *ImagesDownloadCall) FilterURIs(names ...string) *ImagesDownloadCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *ImagesDownloadCall) ExpandTree(expansions ...*Expansion) *ImagesDownloadCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *ImagesDownloadCall) Context(ctx context.Context) *ImagesDownloadCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// LargestImage returns a call for the LargestImage endpoint, described in endpoints/image_largestimage.json.
+func (r *ImagesService) LargestImage(imageKey string) *ImagesLargestImageCall {
+	return &ImagesLargestImageCall{newCall(r.s, "GET", "image/"+imageKey+"!largestimage", "LargestImage", func(res *ImagesLargestImageResponse) interface{} { return &res.LargestImage })}
+}
+
+type ImagesLargestImageCall struct {
+	*Call[ImagesLargestImageResponse]
+}
+
+type ImagesLargestImageResponse struct {
+	LargestImage *LargestImage
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+func (c *ImagesLargestImageCall) Expand(expansions []string) *ImagesLargestImageCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *ImagesLargestImageCall) Filter(filter []string) *ImagesLargestImageCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *ImagesLargestImageCall) FilterURIs(names ...string) *ImagesLargestImageCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *ImagesLargestImageCall) ExpandTree(expansions ...*Expansion) *ImagesLargestImageCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *ImagesLargestImageCall) Context(ctx context.Context) *ImagesLargestImageCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// Metadata returns a call for the ImageMetadata endpoint, described in endpoints/image_metadata.json.
+func (r *ImagesService) Metadata(imageKey string) *ImagesMetadataCall {
+	return &ImagesMetadataCall{newCall(r.s, "GET", "image/"+imageKey+"!metadata", "ImageMetadata", func(res *ImagesMetadataResponse) interface{} { return &res.ImageMetadata })}
+}
+
+type ImagesMetadataCall struct {
+	*Call[ImagesMetadataResponse]
+}
+
+type ImagesMetadataResponse struct {
+	ImageMetadata *ImageMetadata
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+func (c *ImagesMetadataCall) Expand(expansions []string) *ImagesMetadataCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *ImagesMetadataCall) Filter(filter []string) *ImagesMetadataCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *ImagesMetadataCall) FilterURIs(names ...string) *ImagesMetadataCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *ImagesMetadataCall) ExpandTree(expansions ...*Expansion) *ImagesMetadataCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *ImagesMetadataCall) Context(ctx context.Context) *ImagesMetadataCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// SizeDetails returns a call for the ImageSizeDetails endpoint, described in endpoints/image_sizedetails.json.
+func (r *ImagesService) SizeDetails(imageKey string) *ImagesSizeDetailsCall {
+	return &ImagesSizeDetailsCall{newCall(r.s, "GET", "image/"+imageKey+"!sizedetails", "ImageSizeDetails", func(res *ImagesSizeDetailsResponse) interface{} { return &res.ImageSizeDetails })}
+}
+
+type ImagesSizeDetailsCall struct {
+	*Call[ImagesSizeDetailsResponse]
+}
+
+type ImagesSizeDetailsResponse struct {
+	ImageSizeDetails *ImageSizeDetails
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+func (c *ImagesSizeDetailsCall) Expand(expansions []string) *ImagesSizeDetailsCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *ImagesSizeDetailsCall) Filter(filter []string) *ImagesSizeDetailsCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *ImagesSizeDetailsCall) FilterURIs(names ...string) *ImagesSizeDetailsCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *ImagesSizeDetailsCall) ExpandTree(expansions ...*Expansion) *ImagesSizeDetailsCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *ImagesSizeDetailsCall) Context(ctx context.Context) *ImagesSizeDetailsCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// Sizes returns a call for the ImageSizes endpoint, described in endpoints/image_sizes.json.
+func (r *ImagesService) Sizes(imageKey string) *ImagesSizesCall {
+	return &ImagesSizesCall{newCall(r.s, "GET", "image/"+imageKey+"!sizes", "ImageSizes", func(res *ImagesSizesResponse) interface{} { return &res.ImageSizes })}
+}
+
+type ImagesSizesCall struct {
+	*Call[ImagesSizesResponse]
+}
+
+type ImagesSizesResponse struct {
+	ImageSizes *ImageSizes
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+func (c *ImagesSizesCall) Expand(expansions []string) *ImagesSizesCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *ImagesSizesCall) Filter(filter []string) *ImagesSizesCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *ImagesSizesCall) FilterURIs(names ...string) *ImagesSizesCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *ImagesSizesCall) ExpandTree(expansions ...*Expansion) *ImagesSizesCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *ImagesSizesCall) Context(ctx context.Context) *ImagesSizesCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// Children returns a call for the ChildNodes endpoint, described in endpoints/node_children.json.
+func (r *NodesService) Children(nodeID string) *NodesChildrenCall {
+	return &NodesChildrenCall{newCall(r.s, "GET", "node/"+nodeID+"!children", "Node", func(res *NodesChildrenResponse) interface{} { return &res.ChildNodes })}
+}
+
+type NodesChildrenCall struct {
+	*Call[NodesChildrenResponse]
+}
+
+type NodesChildrenResponse struct {
+	ChildNodes []*Node
+	Pages      *Pages
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+// Count sets the "count" parameter. Number of objects to return per page.
+func (c *NodesChildrenCall) Count(v int) *NodesChildrenCall {
+	c.urlParams.Set("count", strconv.Itoa(v))
+	return c
+}
+
+// Start sets the "start" parameter. Index of the first object to return.
+func (c *NodesChildrenCall) Start(v int) *NodesChildrenCall {
+	c.urlParams.Set("start", strconv.Itoa(v))
+	return c
+}
+
+func (c *NodesChildrenCall) Expand(expansions []string) *NodesChildrenCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *NodesChildrenCall) Filter(filter []string) *NodesChildrenCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *NodesChildrenCall) Fields(fields ...NodeField) *NodesChildrenCall {
+	c.Call.Filter(fieldNames(fields))
+	return c
+}
+
+func (c *NodesChildrenCall) FilterURIs(names ...string) *NodesChildrenCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *NodesChildrenCall) ExpandTree(expansions ...*Expansion) *NodesChildrenCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *NodesChildrenCall) Context(ctx context.Context) *NodesChildrenCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// Grants returns a call for the NodeGrants endpoint, described in endpoints/node_grants.json.
+func (r *NodesService) Grants(nodeID string) *NodesGrantsCall {
+	return &NodesGrantsCall{newCall(r.s, "GET", "node/"+nodeID+"!grants", "Grant", func(res *NodesGrantsResponse) interface{} { return &res.NodeGrants })}
+}
+
+type NodesGrantsCall struct {
+	*Call[NodesGrantsResponse]
+}
+
+type NodesGrantsResponse struct {
+	NodeGrants []*Grant
+	Pages      *Pages
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+// Count sets the "count" parameter. Number of objects to return per page.
+func (c *NodesGrantsCall) Count(v int) *NodesGrantsCall {
+	c.urlParams.Set("count", strconv.Itoa(v))
+	return c
+}
+
+// Start sets the "start" parameter. Index of the first object to return.
+func (c *NodesGrantsCall) Start(v int) *NodesGrantsCall {
+	c.urlParams.Set("start", strconv.Itoa(v))
+	return c
+}
+
+func (c *NodesGrantsCall) Expand(expansions []string) *NodesGrantsCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *NodesGrantsCall) Filter(filter []string) *NodesGrantsCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *NodesGrantsCall) FilterURIs(names ...string) *NodesGrantsCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *NodesGrantsCall) ExpandTree(expansions ...*Expansion) *NodesGrantsCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *NodesGrantsCall) Context(ctx context.Context) *NodesGrantsCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// Parents returns a call for the ParentNodes endpoint, described in endpoints/node_parents.json.
+func (r *NodesService) Parents(nodeID string) *NodesParentsCall {
+	return &NodesParentsCall{newCall(r.s, "GET", "node/"+nodeID+"!parents", "Node", func(res *NodesParentsResponse) interface{} { return &res.ParentNodes })}
+}
+
+type NodesParentsCall struct {
+	*Call[NodesParentsResponse]
+}
+
+type NodesParentsResponse struct {
+	ParentNodes []*Node
+	Pages       *Pages
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+// Count sets the "count" parameter. Number of objects to return per page.
+func (c *NodesParentsCall) Count(v int) *NodesParentsCall {
+	c.urlParams.Set("count", strconv.Itoa(v))
+	return c
+}
+
+// Start sets the "start" parameter. Index of the first object to return.
+func (c *NodesParentsCall) Start(v int) *NodesParentsCall {
+	c.urlParams.Set("start", strconv.Itoa(v))
+	return c
+}
+
+func (c *NodesParentsCall) Expand(expansions []string) *NodesParentsCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *NodesParentsCall) Filter(filter []string) *NodesParentsCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *NodesParentsCall) Fields(fields ...NodeField) *NodesParentsCall {
+	c.Call.Filter(fieldNames(fields))
+	return c
+}
+
+func (c *NodesParentsCall) FilterURIs(names ...string) *NodesParentsCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *NodesParentsCall) ExpandTree(expansions ...*Expansion) *NodesParentsCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *NodesParentsCall) Context(ctx context.Context) *NodesParentsCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// Albums returns a call for the UserAlbums endpoint, described in endpoints/user_albums.json.
+func (r *UsersService) Albums(nickname string) *UsersAlbumsCall {
+	return &UsersAlbumsCall{newCall(r.s, "GET", "user/"+nickname+"!albums", "Album", func(res *UsersAlbumsResponse) interface{} { return &res.UserAlbums })}
+}
+
+type UsersAlbumsCall struct {
+	*Call[UsersAlbumsResponse]
+}
+
+type UsersAlbumsResponse struct {
+	UserAlbums []*Album
+	Pages      *Pages
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+// Count sets the "count" parameter. Number of objects to return per page.
+func (c *UsersAlbumsCall) Count(v int) *UsersAlbumsCall {
+	c.urlParams.Set("count", strconv.Itoa(v))
+	return c
+}
+
+// Start sets the "start" parameter. Index of the first object to return.
+func (c *UsersAlbumsCall) Start(v int) *UsersAlbumsCall {
+	c.urlParams.Set("start", strconv.Itoa(v))
+	return c
+}
+
+func (c *UsersAlbumsCall) Expand(expansions []string) *UsersAlbumsCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *UsersAlbumsCall) Filter(filter []string) *UsersAlbumsCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *UsersAlbumsCall) Fields(fields ...AlbumField) *UsersAlbumsCall {
+	c.Call.Filter(fieldNames(fields))
+	return c
+}
+
+func (c *UsersAlbumsCall) FilterURIs(names ...string) *UsersAlbumsCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *UsersAlbumsCall) ExpandTree(expansions ...*Expansion) *UsersAlbumsCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *UsersAlbumsCall) Context(ctx context.Context) *UsersAlbumsCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// FeaturedAlbums returns a call for the UserFeaturedAlbums endpoint, described in endpoints/user_featuredalbums.json.
+func (r *UsersService) FeaturedAlbums(nickname string) *UsersFeaturedAlbumsCall {
+	return &UsersFeaturedAlbumsCall{newCall(r.s, "GET", "user/"+nickname+"!featuredalbums", "Album", func(res *UsersFeaturedAlbumsResponse) interface{} { return &res.UserFeaturedAlbums })}
+}
+
+type UsersFeaturedAlbumsCall struct {
+	*Call[UsersFeaturedAlbumsResponse]
+}
+
+type UsersFeaturedAlbumsResponse struct {
+	UserFeaturedAlbums []*Album
+	Pages              *Pages
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+// Count sets the "count" parameter. Number of objects to return per page.
+func (c *UsersFeaturedAlbumsCall) Count(v int) *UsersFeaturedAlbumsCall {
+	c.urlParams.Set("count", strconv.Itoa(v))
+	return c
+}
+
+// Start sets the "start" parameter. Index of the first object to return.
+func (c *UsersFeaturedAlbumsCall) Start(v int) *UsersFeaturedAlbumsCall {
+	c.urlParams.Set("start", strconv.Itoa(v))
+	return c
+}
+
+func (c *UsersFeaturedAlbumsCall) Expand(expansions []string) *UsersFeaturedAlbumsCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *UsersFeaturedAlbumsCall) Filter(filter []string) *UsersFeaturedAlbumsCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *UsersFeaturedAlbumsCall) Fields(fields ...AlbumField) *UsersFeaturedAlbumsCall {
+	c.Call.Filter(fieldNames(fields))
+	return c
+}
+
+func (c *UsersFeaturedAlbumsCall) FilterURIs(names ...string) *UsersFeaturedAlbumsCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *UsersFeaturedAlbumsCall) ExpandTree(expansions ...*Expansion) *UsersFeaturedAlbumsCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *UsersFeaturedAlbumsCall) Context(ctx context.Context) *UsersFeaturedAlbumsCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// Grants returns a call for the UserGrants endpoint, described in endpoints/user_grants.json.
+func (r *UsersService) Grants(nickname string) *UsersGrantsCall {
+	return &UsersGrantsCall{newCall(r.s, "GET", "user/"+nickname+"!grants", "Grant", func(res *UsersGrantsResponse) interface{} { return &res.UserGrants })}
+}
+
+type UsersGrantsCall struct {
+	*Call[UsersGrantsResponse]
+}
+
+type UsersGrantsResponse struct {
+	UserGrants []*Grant
+	Pages      *Pages
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+// Count sets the "count" parameter. Number of objects to return per page.
+func (c *UsersGrantsCall) Count(v int) *UsersGrantsCall {
+	c.urlParams.Set("count", strconv.Itoa(v))
+	return c
+}
+
+// Start sets the "start" parameter. Index of the first object to return.
+func (c *UsersGrantsCall) Start(v int) *UsersGrantsCall {
+	c.urlParams.Set("start", strconv.Itoa(v))
+	return c
+}
+
+func (c *UsersGrantsCall) Expand(expansions []string) *UsersGrantsCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *UsersGrantsCall) Filter(filter []string) *UsersGrantsCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *UsersGrantsCall) FilterURIs(names ...string) *UsersGrantsCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *UsersGrantsCall) ExpandTree(expansions ...*Expansion) *UsersGrantsCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *UsersGrantsCall) Context(ctx context.Context) *UsersGrantsCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// ImageSearch returns a call for the UserImageSearch endpoint, described in endpoints/user_imagesearch.json.
+func (r *UsersService) ImageSearch(nickname string) *UsersImageSearchCall {
+	return &UsersImageSearchCall{newCall(r.s, "GET", "user/"+nickname+"!imagesearch", "Image", func(res *UsersImageSearchResponse) interface{} { return &res.UserImageSearch })}
+}
+
+type UsersImageSearchCall struct {
+	*Call[UsersImageSearchResponse]
+}
+
+type UsersImageSearchResponse struct {
+	UserImageSearch []*Image
+	Pages           *Pages
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+// Count sets the "count" parameter. Number of objects to return per page.
+func (c *UsersImageSearchCall) Count(v int) *UsersImageSearchCall {
+	c.urlParams.Set("count", strconv.Itoa(v))
+	return c
+}
+
+// Start sets the "start" parameter. Index of the first object to return.
+func (c *UsersImageSearchCall) Start(v int) *UsersImageSearchCall {
+	c.urlParams.Set("start", strconv.Itoa(v))
+	return c
+}
+
+// Scope sets the "Scope" parameter. Uri of the user, folder or album to search within.
+func (c *UsersImageSearchCall) Scope(v string) *UsersImageSearchCall {
+	c.urlParams.Set("Scope", v)
+	return c
+}
+
+// Text sets the "Text" parameter. Words to match against image titles, captions, keywords and file names.
+func (c *UsersImageSearchCall) Text(v string) *UsersImageSearchCall {
+	c.urlParams.Set("Text", v)
+	return c
+}
+
+// Keywords sets the "Keywords" parameter. Keywords every result must carry.
+func (c *UsersImageSearchCall) Keywords(v []string) *UsersImageSearchCall {
+	c.urlParams.Set("Keywords", strings.Join(v, ","))
+	return c
+}
+
+// DateTakenStart sets the "DateTakenStart" parameter. Earliest capture date to include.
+func (c *UsersImageSearchCall) DateTakenStart(v time.Time) *UsersImageSearchCall {
+	c.urlParams.Set("DateTakenStart", v.Format(time.RFC3339))
+	return c
+}
+
+// DateTakenEnd sets the "DateTakenEnd" parameter. Latest capture date to include.
+func (c *UsersImageSearchCall) DateTakenEnd(v time.Time) *UsersImageSearchCall {
+	c.urlParams.Set("DateTakenEnd", v.Format(time.RFC3339))
+	return c
+}
+
+// DateUploadedStart sets the "DateUploadedStart" parameter. Earliest upload date to include.
+func (c *UsersImageSearchCall) DateUploadedStart(v time.Time) *UsersImageSearchCall {
+	c.urlParams.Set("DateUploadedStart", v.Format(time.RFC3339))
+	return c
+}
+
+// DateUploadedEnd sets the "DateUploadedEnd" parameter. Latest upload date to include.
+func (c *UsersImageSearchCall) DateUploadedEnd(v time.Time) *UsersImageSearchCall {
+	c.urlParams.Set("DateUploadedEnd", v.Format(time.RFC3339))
+	return c
+}
+
+// SortMethod sets the "SortMethod" parameter. Order of the results: Relevance, DateTaken, DateUploaded or Popular.
+func (c *UsersImageSearchCall) SortMethod(v SearchSortMethod) *UsersImageSearchCall {
+	c.urlParams.Set("SortMethod", string(v))
+	return c
+}
+
+// SortDirection sets the "SortDirection" parameter. Ascending or Descending.
+func (c *UsersImageSearchCall) SortDirection(v SortDirection) *UsersImageSearchCall {
+	c.urlParams.Set("SortDirection", string(v))
+	return c
+}
+
+func (c *UsersImageSearchCall) Expand(expansions []string) *UsersImageSearchCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *UsersImageSearchCall) Filter(filter []string) *UsersImageSearchCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *UsersImageSearchCall) Fields(fields ...ImageField) *UsersImageSearchCall {
+	c.Call.Filter(fieldNames(fields))
+	return c
+}
+
+func (c *UsersImageSearchCall) FilterURIs(names ...string) *UsersImageSearchCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *UsersImageSearchCall) ExpandTree(expansions ...*Expansion) *UsersImageSearchCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *UsersImageSearchCall) Context(ctx context.Context) *UsersImageSearchCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// PopularMedia returns a call for the UserPopularMedia endpoint, described in endpoints/user_popularmedia.json.
+func (r *UsersService) PopularMedia(nickname string) *UsersPopularMediaCall {
+	return &UsersPopularMediaCall{newCall(r.s, "GET", "user/"+nickname+"!popularmedia", "Image", func(res *UsersPopularMediaResponse) interface{} { return &res.UserPopularMedia })}
+}
+
+type UsersPopularMediaCall struct {
+	*Call[UsersPopularMediaResponse]
+}
+
+type UsersPopularMediaResponse struct {
+	UserPopularMedia []*Image
+	Pages            *Pages
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+// Count sets the "count" parameter. Number of objects to return per page.
+func (c *UsersPopularMediaCall) Count(v int) *UsersPopularMediaCall {
+	c.urlParams.Set("count", strconv.Itoa(v))
+	return c
+}
+
+// Start sets the "start" parameter. Index of the first object to return.
+func (c *UsersPopularMediaCall) Start(v int) *UsersPopularMediaCall {
+	c.urlParams.Set("start", strconv.Itoa(v))
+	return c
+}
+
+func (c *UsersPopularMediaCall) Expand(expansions []string) *UsersPopularMediaCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *UsersPopularMediaCall) Filter(filter []string) *UsersPopularMediaCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *UsersPopularMediaCall) Fields(fields ...ImageField) *UsersPopularMediaCall {
+	c.Call.Filter(fieldNames(fields))
+	return c
+}
+
+func (c *UsersPopularMediaCall) FilterURIs(names ...string) *UsersPopularMediaCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *UsersPopularMediaCall) ExpandTree(expansions ...*Expansion) *UsersPopularMediaCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *UsersPopularMediaCall) Context(ctx context.Context) *UsersPopularMediaCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// RecentImages returns a call for the UserRecentImages endpoint, described in endpoints/user_recentimages.json.
+func (r *UsersService) RecentImages(nickname string) *UsersRecentImagesCall {
+	return &UsersRecentImagesCall{newCall(r.s, "GET", "user/"+nickname+"!recentimages", "Image", func(res *UsersRecentImagesResponse) interface{} { return &res.UserRecentImages })}
+}
+
+type UsersRecentImagesCall struct {
+	*Call[UsersRecentImagesResponse]
+}
+
+type UsersRecentImagesResponse struct {
+	UserRecentImages []*Image
+	Pages            *Pages
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+// Count sets the "count" parameter. Number of objects to return per page.
+func (c *UsersRecentImagesCall) Count(v int) *UsersRecentImagesCall {
+	c.urlParams.Set("count", strconv.Itoa(v))
+	return c
+}
+
+// Start sets the "start" parameter. Index of the first object to return.
+func (c *UsersRecentImagesCall) Start(v int) *UsersRecentImagesCall {
+	c.urlParams.Set("start", strconv.Itoa(v))
+	return c
+}
+
+func (c *UsersRecentImagesCall) Expand(expansions []string) *UsersRecentImagesCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *UsersRecentImagesCall) Filter(filter []string) *UsersRecentImagesCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *UsersRecentImagesCall) Fields(fields ...ImageField) *UsersRecentImagesCall {
+	c.Call.Filter(fieldNames(fields))
+	return c
+}
+
+func (c *UsersRecentImagesCall) FilterURIs(names ...string) *UsersRecentImagesCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *UsersRecentImagesCall) ExpandTree(expansions ...*Expansion) *UsersRecentImagesCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *UsersRecentImagesCall) Context(ctx context.Context) *UsersRecentImagesCall {
+	c.Call.Context(ctx)
+	return c
+}
@@ -0,0 +1,90 @@
+package smugmug_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestGeneratedEndpoints(t *testing.T) {
+	fake, s, root := newFake(t)
+	folder := fake.AddNode(root, &smugmug.Node{Name: "Travel", Type: smugmug.NodeTypeFolder})
+	a := fake.AddAlbum(folder.NodeID, &smugmug.Album{Name: "Paris"})
+	var keys []string
+	for _, name := range []string{"a.jpg", "b.jpg", "c.jpg"} {
+		img := fake.AddImage(a.AlbumKey, &smugmug.Image{FileName: name}, []byte(name))
+		keys = append(keys, img.ImageKey)
+	}
+	fake.SetImageMetadata(keys[0], &smugmug.ImageMetadata{Title: "Eiffel Tower"})
+
+	ctx := context.Background()
+
+	var got []*smugmug.Image
+	call := s.Albums.Images(a.AlbumKey).Count(2)
+	err := call.Pages(ctx, func(res *smugmug.AlbumsImagesResponse) error {
+		if res.Pages == nil || res.Pages.Total != 3 {
+			t.Errorf("Pages = %+v", res.Pages)
+		}
+		got = append(got, res.AlbumImages...)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names(got) != "a.jpg, b.jpg, c.jpg" {
+		t.Errorf("AlbumImages = %s", names(got))
+	}
+
+	children, err := s.Nodes.Children(folder.NodeID).Expand([]string{"Album"}).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(children.ChildNodes) != 1 {
+		t.Fatalf("ChildNodes = %+v", children.ChildNodes)
+	}
+	if album, ok := smugmug.ExpansionOf[*smugmug.Album](children.ChildNodes[0].Expansions, "Album"); !ok || album.AlbumKey != a.AlbumKey {
+		t.Errorf("Album expansion = %+v", album)
+	}
+
+	empty := fake.AddNode(root, &smugmug.Node{Name: "Empty", Type: smugmug.NodeTypeFolder})
+	none, err := s.Nodes.Children(empty.NodeID).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(none.ChildNodes) != 0 || none.Pages == nil || none.Pages.Total != 0 {
+		t.Errorf("empty children = %+v", none)
+	}
+
+	withImages, err := s.Albums.Get(a.AlbumKey).Expand([]string{"AlbumImages"}).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(withImages.AlbumImages) != 3 {
+		t.Errorf("AlbumImages expansion = %+v", withImages.AlbumImages)
+	}
+
+	parents, err := s.Nodes.Parents(a.NodeID).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parents.ParentNodes) != 2 || parents.ParentNodes[0].NodeID != folder.NodeID {
+		t.Errorf("ParentNodes = %+v", parents.ParentNodes)
+	}
+
+	md, err := s.Images.Metadata(keys[0]).Context(ctx).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md.ImageMetadata.Title != "Eiffel Tower" {
+		t.Errorf("ImageMetadata = %+v", md.ImageMetadata)
+	}
+
+	img, err := s.Images.Get(keys[1]).Expand([]string{"LargestImage"}).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if largest, ok := smugmug.ExpansionOf[*smugmug.LargestImage](img.Image.Expansions, "LargestImage"); !ok || largest.URL == "" {
+		t.Errorf("LargestImage expansion = %+v", largest)
+	}
+}
@@ -0,0 +1,200 @@
+package smugmug
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type NodeType string
+
+const (
+	NodeTypeAlbum       NodeType = "Album"
+	NodeTypeFolder      NodeType = "Folder"
+	NodeTypePage        NodeType = "Page"
+	NodeTypeSystemAlbum NodeType = "System Album"
+)
+
+var nodeTypes = []NodeType{NodeTypeAlbum, NodeTypeFolder, NodeTypePage, NodeTypeSystemAlbum}
+
+func (v NodeType) Valid() bool                   { return validEnum(v, nodeTypes) }
+func (v NodeType) MarshalJSON() ([]byte, error)  { return marshalEnum(v) }
+func (v *NodeType) UnmarshalJSON(b []byte) error { return unmarshalEnum(b, v) }
+
+type Privacy string
+
+const (
+	PrivacyPublic   Privacy = "Public"
+	PrivacyUnlisted Privacy = "Unlisted"
+	PrivacyPrivate  Privacy = "Private"
+)
+
+var privacies = []Privacy{PrivacyPublic, PrivacyUnlisted, PrivacyPrivate}
+
+func (v Privacy) Valid() bool                   { return validEnum(v, privacies) }
+func (v Privacy) MarshalJSON() ([]byte, error)  { return marshalEnum(v) }
+func (v *Privacy) UnmarshalJSON(b []byte) error { return unmarshalEnum(b, v) }
+
+type SecurityType string
+
+const (
+	SecurityTypeNone        SecurityType = "None"
+	SecurityTypePassword    SecurityType = "Password"
+	SecurityTypeGrantAccess SecurityType = "GrantAccess"
+)
+
+var securityTypes = []SecurityType{SecurityTypeNone, SecurityTypePassword, SecurityTypeGrantAccess}
+
+func (v SecurityType) Valid() bool                   { return validEnum(v, securityTypes) }
+func (v SecurityType) MarshalJSON() ([]byte, error)  { return marshalEnum(v) }
+func (v *SecurityType) UnmarshalJSON(b []byte) error { return unmarshalEnum(b, v) }
+
+// SortMethod covers both folder (node) and album image ordering; folders
+// use SortIndex, Name, DateAdded and DateModified.
+type SortMethod string
+
+const (
+	SortMethodSortIndex    SortMethod = "SortIndex"
+	SortMethodName         SortMethod = "Name"
+	SortMethodDateAdded    SortMethod = "DateAdded"
+	SortMethodDateModified SortMethod = "DateModified"
+
+	SortMethodPosition          SortMethod = "Position"
+	SortMethodCaption           SortMethod = "Caption"
+	SortMethodFileName          SortMethod = "FileName"
+	SortMethodDateUploaded      SortMethod = "Date Uploaded"
+	SortMethodImageDateModified SortMethod = "Date Modified"
+	SortMethodDateTaken         SortMethod = "Date Taken"
+)
+
+var sortMethods = []SortMethod{
+	SortMethodSortIndex, SortMethodName, SortMethodDateAdded, SortMethodDateModified,
+	SortMethodPosition, SortMethodCaption, SortMethodFileName, SortMethodDateUploaded,
+	SortMethodImageDateModified, SortMethodDateTaken,
+}
+
+func (v SortMethod) Valid() bool                   { return validEnum(v, sortMethods) }
+func (v SortMethod) MarshalJSON() ([]byte, error)  { return marshalEnum(v) }
+func (v *SortMethod) UnmarshalJSON(b []byte) error { return unmarshalEnum(b, v) }
+
+type SortDirection string
+
+const (
+	SortDirectionAscending  SortDirection = "Ascending"
+	SortDirectionDescending SortDirection = "Descending"
+)
+
+var sortDirections = []SortDirection{SortDirectionAscending, SortDirectionDescending}
+
+func (v SortDirection) Valid() bool                   { return validEnum(v, sortDirections) }
+func (v SortDirection) MarshalJSON() ([]byte, error)  { return marshalEnum(v) }
+func (v *SortDirection) UnmarshalJSON(b []byte) error { return unmarshalEnum(b, v) }
+
+// SearchSortMethod orders image search results. Its values differ from the
+// album SortMethod ones, e.g. DateTaken rather than "Date Taken".
+type SearchSortMethod string
+
+const (
+	SearchSortMethodRelevance    SearchSortMethod = "Relevance"
+	SearchSortMethodDateTaken    SearchSortMethod = "DateTaken"
+	SearchSortMethodDateUploaded SearchSortMethod = "DateUploaded"
+	SearchSortMethodPopular      SearchSortMethod = "Popular"
+)
+
+var searchSortMethods = []SearchSortMethod{
+	SearchSortMethodRelevance, SearchSortMethodDateTaken, SearchSortMethodDateUploaded, SearchSortMethodPopular,
+}
+
+func (v SearchSortMethod) Valid() bool                   { return validEnum(v, searchSortMethods) }
+func (v SearchSortMethod) MarshalJSON() ([]byte, error)  { return marshalEnum(v) }
+func (v *SearchSortMethod) UnmarshalJSON(b []byte) error { return unmarshalEnum(b, v) }
+
+// MoveLocation says on which side of its target SortImages puts the images
+// it moves.
+type MoveLocation string
+
+const (
+	MoveBefore MoveLocation = "Before"
+	MoveAfter  MoveLocation = "After"
+)
+
+var moveLocations = []MoveLocation{MoveBefore, MoveAfter}
+
+func (v MoveLocation) Valid() bool                   { return validEnum(v, moveLocations) }
+func (v MoveLocation) MarshalJSON() ([]byte, error)  { return marshalEnum(v) }
+func (v *MoveLocation) UnmarshalJSON(b []byte) error { return unmarshalEnum(b, v) }
+
+type SmugSearchable string
+
+const (
+	SmugSearchableNo          SmugSearchable = "No"
+	SmugSearchableLocal       SmugSearchable = "Local"
+	SmugSearchableLocalUser   SmugSearchable = "LocalUser"
+	SmugSearchableYes         SmugSearchable = "Yes"
+	SmugSearchableInheritUser SmugSearchable = "Inherit from User"
+)
+
+var smugSearchables = []SmugSearchable{
+	SmugSearchableNo, SmugSearchableLocal, SmugSearchableLocalUser, SmugSearchableYes, SmugSearchableInheritUser,
+}
+
+func (v SmugSearchable) Valid() bool                   { return validEnum(v, smugSearchables) }
+func (v SmugSearchable) MarshalJSON() ([]byte, error)  { return marshalEnum(v) }
+func (v *SmugSearchable) UnmarshalJSON(b []byte) error { return unmarshalEnum(b, v) }
+
+type WorldSearchable string
+
+const (
+	WorldSearchableNo          WorldSearchable = "No"
+	WorldSearchableHomeOnly    WorldSearchable = "HomeOnly"
+	WorldSearchableYes         WorldSearchable = "Yes"
+	WorldSearchableInheritUser WorldSearchable = "Inherit from User"
+)
+
+var worldSearchables = []WorldSearchable{
+	WorldSearchableNo, WorldSearchableHomeOnly, WorldSearchableYes, WorldSearchableInheritUser,
+}
+
+func (v WorldSearchable) Valid() bool                  { return validEnum(v, worldSearchables) }
+func (v WorldSearchable) MarshalJSON() ([]byte, error) { return marshalEnum(v) }
+
+// UnmarshalJSON also accepts the booleans older album payloads used.
+func (v *WorldSearchable) UnmarshalJSON(b []byte) error {
+	var flag bool
+	if err := json.Unmarshal(b, &flag); err == nil {
+		*v = WorldSearchableNo
+		if flag {
+			*v = WorldSearchableYes
+		}
+		return nil
+	}
+	return unmarshalEnum(b, v)
+}
+
+func validEnum[T ~string](v T, values []T) bool {
+	for _, value := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// marshalEnum and unmarshalEnum keep values outside the known set as they
+// are, so that a value SmugMug adds later survives a read-modify-write;
+// Valid reports them, and Validate and strict mode reject them.
+func marshalEnum[T ~string](v T) ([]byte, error) {
+	return json.Marshal(string(v))
+}
+
+func unmarshalEnum[T ~string](b []byte, v *T) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("smugmug: %T: %v", *v, err)
+	}
+	*v = T(s)
+	return nil
+}
+
+func (n *Node) IsAlbum() bool  { return n.Type == NodeTypeAlbum }
+func (n *Node) IsFolder() bool { return n.Type == NodeTypeFolder }
+func (n *Node) IsPage() bool   { return n.Type == NodeTypePage }
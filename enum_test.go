@@ -0,0 +1,64 @@
+package smugmug
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumJSON(t *testing.T) {
+	var n Node
+	if err := json.Unmarshal([]byte(`{"Type":"Folder","Privacy":"Unlisted","SortMethod":"DateAdded"}`), &n); err != nil {
+		t.Fatal(err)
+	}
+	if !n.IsFolder() || n.IsAlbum() || n.Privacy != PrivacyUnlisted || n.SortMethod != SortMethodDateAdded {
+		t.Errorf("unexpected node %+v", n)
+	}
+	var unknown Album
+	if err := json.Unmarshal([]byte(`{"Name":"Paris","SortMethod":"Random"}`), &unknown); err != nil {
+		t.Fatalf("unknown sort method: %v", err)
+	}
+	if unknown.SortMethod != "Random" || unknown.SortMethod.Valid() {
+		t.Errorf("SortMethod = %q, valid %v", unknown.SortMethod, unknown.SortMethod.Valid())
+	}
+	if err := (&Service{}).checkStrict(&unknown); err != nil {
+		t.Errorf("lenient: %v", err)
+	}
+	err := (&Service{Strict: true}).checkStrict(&unknown)
+	if uerr, ok := err.(*UnknownFieldsError); !ok || len(uerr.Fields["Album"]) != 1 || uerr.Fields["Album"][0] != `SortMethod="Random"` {
+		t.Errorf("strict: %v", err)
+	}
+	data, err := json.Marshal(&unknown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var again Album
+	if err := json.Unmarshal(data, &again); err != nil {
+		t.Fatal(err)
+	}
+	if again.SortMethod != "Random" {
+		t.Errorf("round trip: SortMethod = %q", again.SortMethod)
+	}
+	data, err = json.Marshal(&Node{Type: NodeTypeAlbum})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"Type":"Album"}` {
+		t.Errorf("got %s", data)
+	}
+}
+
+func TestWorldSearchableBool(t *testing.T) {
+	for in, want := range map[string]WorldSearchable{
+		`true`:       WorldSearchableYes,
+		`false`:      WorldSearchableNo,
+		`"HomeOnly"`: WorldSearchableHomeOnly,
+	} {
+		var v WorldSearchable
+		if err := json.Unmarshal([]byte(in), &v); err != nil {
+			t.Fatal(err)
+		}
+		if v != want {
+			t.Errorf("%s: got %q, want %q", in, v, want)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package smugmug
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Expansion is a node in a multi-level expansion tree, sent to the API as
+// the _config parameter:
+//
+//	NewExpansion("ChildNodes").Arg("count", 50).Expand(
+//		NewExpansion("Album").Expand(
+//			NewExpansion("HighlightImage").Expand(
+//				NewExpansion("ImageSizes"))))
+type Expansion struct {
+	name     string
+	args     map[string]interface{}
+	filter   []string
+	children []*Expansion
+}
+
+func NewExpansion(name string) *Expansion {
+	return &Expansion{name: name}
+}
+
+// Expand adds expansions of the objects returned by e.
+func (e *Expansion) Expand(children ...*Expansion) *Expansion {
+	e.children = append(e.children, children...)
+	return e
+}
+
+// Filter limits the fields returned for the objects of e.
+func (e *Expansion) Filter(fields ...string) *Expansion {
+	e.filter = append(e.filter, fields...)
+	return e
+}
+
+// Arg sets a parameter of the expanded endpoint, e.g. "count".
+func (e *Expansion) Arg(key string, value interface{}) *Expansion {
+	if e.args == nil {
+		e.args = map[string]interface{}{}
+	}
+	e.args[key] = value
+	return e
+}
+
+func (e *Expansion) config() map[string]interface{} {
+	ret := map[string]interface{}{}
+	if len(e.args) > 0 {
+		ret["args"] = e.args
+	}
+	if len(e.filter) > 0 {
+		ret["filter"] = e.filter
+	}
+	if len(e.children) > 0 {
+		ret["expand"] = expandConfig(e.children)
+	}
+	return ret
+}
+
+func expandConfig(expansions []*Expansion) map[string]interface{} {
+	ret := map[string]interface{}{}
+	for _, e := range expansions {
+		ret[e.name] = e.config()
+	}
+	return ret
+}
+
+func configParam(expansions []*Expansion) string {
+	data, _ := json.Marshal(map[string]interface{}{"expand": expandConfig(expansions)})
+	return string(data)
+}
+
+// Expansions holds the expansions resolved for an object, keyed by name.
+// Values are the decoded types the response fields use (e.g. *Album,
+// []*Node) or json.RawMessage for expansions the package doesn't model.
+type Expansions map[string]interface{}
+
+// ExpansionOf returns the expansion name of an object as a T.
+func ExpansionOf[T any](e Expansions, name string) (T, bool) {
+	v, ok := e[name].(T)
+	return v, ok
+}
+
+type expandable interface {
+	uris() *URIs
+	setExpansions(exp Expansions)
+}
+
+func (a *Album) uris() *URIs                    { return a.URIs }
+func (a *Album) setExpansions(exp Expansions)   { a.Expansions = exp }
+func (c *Comment) uris() *URIs                  { return c.URIs }
+func (c *Comment) setExpansions(exp Expansions) { c.Expansions = exp }
+func (g *Grant) uris() *URIs                    { return g.URIs }
+func (g *Grant) setExpansions(exp Expansions)   { g.Expansions = exp }
+func (i *Image) uris() *URIs                    { return i.URIs }
+func (i *Image) setExpansions(exp Expansions)   { i.Expansions = exp }
+func (n *Node) uris() *URIs                     { return n.URIs }
+func (n *Node) setExpansions(exp Expansions)    { n.Expansions = exp }
+func (u *User) uris() *URIs                     { return u.URIs }
+func (u *User) setExpansions(exp Expansions)    { u.Expansions = exp }
+
+type expansionDecoder func(raw json.RawMessage) (interface{}, error)
+
+// expansionDecoders maps an expansion name, as it appears in Uris, to the
+// decoder for its payload. Expansions missing here are kept raw. Endpoints
+// described in endpoints/ register theirs in endpoints_gen.go.
+var expansionDecoders = map[string]expansionDecoder{
+	"Album":          decodeObject[Album]("Album"),
+	"HighlightImage": decodeObject[Image]("Image"),
+	"ImageAlbum":     decodeObject[Album]("Album"),
+	"ImageOwner":     decodeObject[User]("User"),
+	"ImagePrices":    decodeObjects[CatalogSkuPrice]("CatalogSkuPrice"),
+	"Node":           decodeObject[Node]("Node"),
+	"ParentNode":     decodeObject[Node]("Node"),
+	"User":           decodeObject[User]("User"),
+}
+
+// decodeObject decodes a payload of the form {"<locator>": {...}} into a *T.
+func decodeObject[T any](locator string) expansionDecoder {
+	return func(raw json.RawMessage) (interface{}, error) {
+		var v *T
+		return v, decodeLocator(raw, locator, &v)
+	}
+}
+
+// decodeObjects decodes a payload of the form {"<locator>": [...]} into a []*T.
+func decodeObjects[T any](locator string) expansionDecoder {
+	return func(raw json.RawMessage) (interface{}, error) {
+		var v []*T
+		return v, decodeLocator(raw, locator, &v)
+	}
+}
+
+func decodeLocator(raw json.RawMessage, locator string, v interface{}) error {
+	env := map[string]json.RawMessage{}
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return err
+	}
+	data, ok := env[locator]
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+func unmarshallExpansions(uris *URIs, exp map[string]*json.RawMessage) (map[string]interface{}, error) {
+	return unmarshallNested(uris, exp, map[string]bool{})
+}
+
+// unmarshallNested decodes the expansions of one object and, recursively,
+// those of the objects it expanded to. seen holds the URIs on the current
+// path so that cycles such as Node -> User -> Node terminate.
+func unmarshallNested(uris *URIs, exp map[string]*json.RawMessage, seen map[string]bool) (map[string]interface{}, error) {
+	ret := map[string]interface{}{}
+	if uris == nil {
+		return ret, nil
+	}
+	for name, uri := range *uris {
+		u := parseURI(uri)
+		value, ok := exp[u]
+		if !ok || value == nil {
+			continue
+		}
+		decode, ok := expansionDecoders[name]
+		if !ok {
+			ret[name] = *value
+			continue
+		}
+		v, err := decode(*value)
+		if err != nil {
+			return nil, err
+		}
+		ret[name] = v
+		if seen[u] {
+			continue
+		}
+		seen[u] = true
+		for _, obj := range expandables(v) {
+			nested, err := unmarshallNested(obj.uris(), exp, seen)
+			if err != nil {
+				return nil, err
+			}
+			if len(nested) > 0 {
+				obj.setExpansions(nested)
+			}
+		}
+		delete(seen, u)
+	}
+	return ret, nil
+}
+
+func expandables(v interface{}) []expandable {
+	if obj, ok := v.(expandable); ok {
+		if reflect.ValueOf(obj).IsNil() {
+			return nil
+		}
+		return []expandable{obj}
+	}
+	var ret []expandable
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+		for i := 0; i < rv.Len(); i++ {
+			if obj, ok := rv.Index(i).Interface().(expandable); ok && !rv.Index(i).IsNil() {
+				ret = append(ret, obj)
+			}
+		}
+	}
+	return ret
+}
+
+// decodeExpansions resolves the expansions of obj, attaches them to it, and
+// stores each in the field of the same name on dst, a pointer to a response
+// struct. Expansions without a matching field are returned raw.
+func decodeExpansions(dst interface{}, obj expandable, exp map[string]*json.RawMessage) (map[string]json.RawMessage, error) {
+	uris := obj.uris()
+	values, err := unmarshallExpansions(uris, exp)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) > 0 {
+		obj.setExpansions(values)
+	}
+	var other map[string]json.RawMessage
+	v := reflect.ValueOf(dst).Elem()
+	for name, value := range values {
+		f := v.FieldByName(name)
+		if f.IsValid() && f.CanSet() && reflect.TypeOf(value).AssignableTo(f.Type()) {
+			f.Set(reflect.ValueOf(value))
+			continue
+		}
+		if other == nil {
+			other = map[string]json.RawMessage{}
+		}
+		other[name] = *exp[parseURI((*uris)[name])]
+	}
+	return other, nil
+}
@@ -0,0 +1,289 @@
+package smugmug
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// knownFields caches, per struct type, the lowercased JSON names that
+// encoding/json would decode into. Matching is case-insensitive like the
+// decoder's.
+var knownFields sync.Map // reflect.Type -> map[string]bool
+
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	if names, ok := knownFields.Load(t); ok {
+		return names.(map[string]bool)
+	}
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[strings.ToLower(name)] = true
+	}
+	knownFields.Store(t, names)
+	return names
+}
+
+// extraFields returns the keys of the JSON object in data that have no
+// corresponding field in v, or nil if there are none.
+func extraFields(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	known := jsonFieldNames(reflect.TypeOf(v))
+	var extra map[string]json.RawMessage
+	for k, raw := range obj {
+		if known[strings.ToLower(k)] {
+			continue
+		}
+		if extra == nil {
+			extra = map[string]json.RawMessage{}
+		}
+		extra[k] = raw
+	}
+	return extra, nil
+}
+
+// marshalWithExtra encodes v and appends the extra keys, sorted, to the
+// resulting object. Keys that v already encodes win.
+func marshalWithExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	known := jsonFieldNames(reflect.TypeOf(v))
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		if !known[strings.ToLower(k)] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	sep := len(data) > 2
+	for _, k := range keys {
+		if sep {
+			buf.WriteByte(',')
+		}
+		sep = true
+		name, _ := json.Marshal(k)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(extra[k])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (a *Album) UnmarshalJSON(data []byte) error {
+	type album Album
+	if err := json.Unmarshal(data, (*album)(a)); err != nil {
+		return err
+	}
+	var err error
+	a.Extra, err = extraFields(data, album{})
+	return err
+}
+
+func (a Album) MarshalJSON() ([]byte, error) {
+	type album Album
+	return marshalWithExtra(album(a), a.Extra)
+}
+
+func (c *Comment) UnmarshalJSON(data []byte) error {
+	type comment Comment
+	if err := json.Unmarshal(data, (*comment)(c)); err != nil {
+		return err
+	}
+	var err error
+	c.Extra, err = extraFields(data, comment{})
+	return err
+}
+
+func (c Comment) MarshalJSON() ([]byte, error) {
+	type comment Comment
+	return marshalWithExtra(comment(c), c.Extra)
+}
+
+func (g *Grant) UnmarshalJSON(data []byte) error {
+	type grant Grant
+	if err := json.Unmarshal(data, (*grant)(g)); err != nil {
+		return err
+	}
+	var err error
+	g.Extra, err = extraFields(data, grant{})
+	return err
+}
+
+func (g Grant) MarshalJSON() ([]byte, error) {
+	type grant Grant
+	return marshalWithExtra(grant(g), g.Extra)
+}
+
+func (i *Image) UnmarshalJSON(data []byte) error {
+	type image Image
+	if err := json.Unmarshal(data, (*image)(i)); err != nil {
+		return err
+	}
+	var err error
+	i.Extra, err = extraFields(data, image{})
+	return err
+}
+
+func (i Image) MarshalJSON() ([]byte, error) {
+	type image Image
+	return marshalWithExtra(image(i), i.Extra)
+}
+
+func (m *ImageMetadata) UnmarshalJSON(data []byte) error {
+	type imageMetadata ImageMetadata
+	if err := json.Unmarshal(data, (*imageMetadata)(m)); err != nil {
+		return err
+	}
+	var err error
+	m.Extra, err = extraFields(data, imageMetadata{})
+	return err
+}
+
+func (m ImageMetadata) MarshalJSON() ([]byte, error) {
+	type imageMetadata ImageMetadata
+	return marshalWithExtra(imageMetadata(m), m.Extra)
+}
+
+func (n *Node) UnmarshalJSON(data []byte) error {
+	type node Node
+	if err := json.Unmarshal(data, (*node)(n)); err != nil {
+		return err
+	}
+	var err error
+	n.Extra, err = extraFields(data, node{})
+	return err
+}
+
+func (n Node) MarshalJSON() ([]byte, error) {
+	type node Node
+	return marshalWithExtra(node(n), n.Extra)
+}
+
+func (u *User) UnmarshalJSON(data []byte) error {
+	type user User
+	if err := json.Unmarshal(data, (*user)(u)); err != nil {
+		return err
+	}
+	var err error
+	u.Extra, err = extraFields(data, user{})
+	return err
+}
+
+func (u User) MarshalJSON() ([]byte, error) {
+	type user User
+	return marshalWithExtra(user(u), u.Extra)
+}
+
+// UnknownFieldsError is returned in strict mode when a response contains
+// keys the models do not define, or enum values the client does not know.
+// Fields maps a model name to its unknown keys; unknown enum values are
+// listed as Field="value".
+type UnknownFieldsError struct {
+	Fields map[string][]string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	var names []string
+	for model, keys := range e.Fields {
+		for _, k := range keys {
+			names = append(names, model+"."+k)
+		}
+	}
+	sort.Strings(names)
+	return "smugmug: unknown fields: " + strings.Join(names, ", ")
+}
+
+// checkStrict walks v, including expansions, and reports any Extra keys and
+// invalid enum values when the service is in strict mode.
+func (s *Service) checkStrict(v interface{}) error {
+	if !s.Strict {
+		return nil
+	}
+	fields := map[string][]string{}
+	collectExtra(reflect.ValueOf(v), fields, map[uintptr]bool{})
+	if len(fields) == 0 {
+		return nil
+	}
+	for _, keys := range fields {
+		sort.Strings(keys)
+	}
+	return &UnknownFieldsError{Fields: fields}
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// enum is implemented by the string enums in enum.go.
+type enum interface {
+	Valid() bool
+}
+
+func collectExtra(v reflect.Value, fields map[string][]string, seen map[uintptr]bool) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || seen[v.Pointer()] {
+			return
+		}
+		seen[v.Pointer()] = true
+		collectExtra(v.Elem(), fields, seen)
+	case reflect.Interface:
+		if !v.IsNil() {
+			collectExtra(v.Elem(), fields, seen)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			if f.Name == "Extra" && f.Type.Kind() == reflect.Map {
+				for _, k := range v.Field(i).MapKeys() {
+					fields[v.Type().Name()] = append(fields[v.Type().Name()], k.String())
+				}
+				continue
+			}
+			if e, ok := v.Field(i).Interface().(enum); ok && v.Field(i).Kind() == reflect.String {
+				if s := v.Field(i).String(); s != "" && !e.Valid() {
+					fields[v.Type().Name()] = append(fields[v.Type().Name()], fmt.Sprintf("%s=%q", f.Name, s))
+				}
+				continue
+			}
+			collectExtra(v.Field(i), fields, seen)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type() == rawMessageType || v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			collectExtra(v.Index(i), fields, seen)
+		}
+	case reflect.Map:
+		if v.Type().Elem() == rawMessageType {
+			return
+		}
+		for _, k := range v.MapKeys() {
+			collectExtra(v.MapIndex(k), fields, seen)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package smugmug_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestExtraRoundTrip(t *testing.T) {
+	in := `{"Name":"Paris","NewThing":{"On":true},"Type":"Album"}`
+	var n smugmug.Node
+	if err := json.Unmarshal([]byte(in), &n); err != nil {
+		t.Fatal(err)
+	}
+	if string(n.Extra["NewThing"]) != `{"On":true}` || len(n.Extra) != 1 {
+		t.Errorf("Extra = %v", n.Extra)
+	}
+	out, err := json.Marshal(&n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"Name":"Paris","Type":"Album","NewThing":{"On":true}}`; string(out) != want {
+		t.Errorf("got %s, want %s", out, want)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	fake, s, root := newFake(t)
+	a := fake.AddAlbum(root, &smugmug.Album{
+		Name:  "Paris",
+		Extra: map[string]json.RawMessage{"FutureSetting": json.RawMessage(`"Corner"`)},
+	})
+
+	res, err := s.Albums.GetAlbum(context.Background(), a.AlbumKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res.Album.Extra["FutureSetting"]) != `"Corner"` {
+		t.Errorf("Extra = %v", res.Album.Extra)
+	}
+
+	s, err = smugmug.New(fake.Client(), smugmug.WithBaseURL(fake.URL), smugmug.WithStrict())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Albums.GetAlbum(context.Background(), a.AlbumKey)
+	var unknown *smugmug.UnknownFieldsError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("expected UnknownFieldsError, got %v", err)
+	}
+	if got := unknown.Fields["Album"]; len(got) != 1 || got[0] != "FutureSetting" {
+		t.Errorf("Fields = %v", unknown.Fields)
+	}
+	var album *smugmug.Album
+	if err := s.Get(context.Background(), "album/"+a.AlbumKey, &album); !errors.As(err, &unknown) {
+		t.Errorf("Get: expected UnknownFieldsError, got %v", err)
+	}
+	if _, err := s.Nodes.GetNode(context.Background(), root); err != nil {
+		t.Errorf("GetNode: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package smugmug
+
+import (
+	"net/url"
+	"strings"
+)
+
+//go:generate go run ./internal/fieldgen -o fields_gen.go album.go image.go node.go user.go
+
+// Fields limits the response to the given fields, e.g.
+// Fields(AlbumFieldName, AlbumFieldImageCount). Unlike Filter the names are
+// checked by the compiler.
+func Fields[F AlbumField | ImageField | NodeField | UserField](fields ...F) CallOption {
+	return Filter(fieldNames(fields)...)
+}
+
+// FilterURIs limits the Uris returned with each object to the given names.
+func FilterURIs(names ...string) CallOption {
+	return callOption(func(params url.Values) {
+		params.Set("_filteruri", strings.Join(names, ","))
+	})
+}
+
+func fieldNames[F ~string](fields []F) []string {
+	ret := make([]string, len(fields))
+	for i, f := range fields {
+		ret[i] = string(f)
+	}
+	return ret
+}
+
+// FieldSet records the _filter and _filteruri a response was requested
+// with, so that fields trimmed by the API can be told apart from empty ones.
+type FieldSet struct {
+	Fields []string
+	URIs   []string
+}
+
+func requestedFields(params url.Values) FieldSet {
+	return FieldSet{
+		Fields: splitParam(params.Get("_filter")),
+		URIs:   splitParam(params.Get("_filteruri")),
+	}
+}
+
+// HasField reports whether the field was requested; every field is when no
+// filter was given.
+func (f FieldSet) HasField(name string) bool {
+	return f.Fields == nil || contains(f.Fields, name)
+}
+
+// HasURI reports whether the named Uris entry was requested.
+func (f FieldSet) HasURI(name string) bool {
+	return f.URIs == nil || contains(f.URIs, name)
+}
+
+func splitParam(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
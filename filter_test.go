@@ -0,0 +1,49 @@
+package smugmug_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestFields(t *testing.T) {
+	fake, s, root := newFake(t)
+	a := fake.AddAlbum(root, &smugmug.Album{Name: "Paris", Description: "Spring"})
+
+	res, err := s.Albums.Get(a.AlbumKey).
+		Fields(smugmug.AlbumFieldName, smugmug.AlbumFieldAlbumKey).
+		FilterURIs("Node").
+		Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Album.Name != "Paris" || res.Album.Description != "" {
+		t.Errorf("Album = %+v", res.Album)
+	}
+	if !res.Requested.HasField(string(smugmug.AlbumFieldName)) || res.Requested.HasField(string(smugmug.AlbumFieldDescription)) {
+		t.Errorf("Requested = %+v", res.Requested)
+	}
+	if res.Album.URIs == nil || len(*res.Album.URIs) != 1 || (*res.Album.URIs)["Node"] == nil {
+		t.Errorf("Uris = %v", res.Album.URIs)
+	}
+	if !res.Requested.HasURI("Node") || res.Requested.HasURI("User") {
+		t.Errorf("Requested = %+v", res.Requested)
+	}
+
+	nres, err := s.Nodes.GetNode(context.Background(), a.NodeID, smugmug.Fields(smugmug.NodeFieldType))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nres.Node.Type != "Album" || nres.Node.Name != "" {
+		t.Errorf("Node = %+v", nres.Node)
+	}
+
+	full, err := s.Albums.Get(a.AlbumKey).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !full.Requested.HasField("Description") || full.Album.Description != "Spring" {
+		t.Errorf("unfiltered response = %+v", full)
+	}
+}
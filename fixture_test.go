@@ -0,0 +1,71 @@
+package smugmug_test
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+	"github.com/pilwon/go-smugmug/smugmugtest"
+)
+
+// nickname is the user newFake creates.
+const nickname = "cmac"
+
+// newFake starts a fake server holding the user nickname and returns it with
+// a service talking to it and the ID of the user's root folder. The server
+// is closed when the test ends.
+func newFake(t *testing.T) (*smugmugtest.Server, *smugmug.Service, string) {
+	t.Helper()
+	fake := smugmugtest.NewServer()
+	t.Cleanup(fake.Close)
+	fake.AddUser(&smugmug.User{NickName: nickname})
+	s, err := fake.Service()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fake, s, fake.RootNode(nickname).NodeID
+}
+
+// names lists the file names of images and the names of everything else as
+// "a, b, c", to compare a listing in one line.
+func names[T any](items []T) string {
+	ret := make([]string, len(items))
+	for i, item := range items {
+		switch v := any(item).(type) {
+		case *smugmug.Image:
+			ret[i] = v.FileName
+		case *smugmug.Node:
+			ret[i] = v.Name
+		case *smugmug.Album:
+			ret[i] = v.Name
+		case *zip.File:
+			ret[i] = v.Name
+		default:
+			panic(fmt.Sprintf("names: unsupported %T", item))
+		}
+	}
+	return strings.Join(ret, ", ")
+}
+
+// albumNames lists the file names of the images in album albumKey.
+func albumNames(t *testing.T, s *smugmug.Service, albumKey string) string {
+	t.Helper()
+	res, err := s.Albums.Images(albumKey).Context(context.Background()).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return names(res.AlbumImages)
+}
+
+// childNames lists the names of the children of folderID.
+func childNames(t *testing.T, s *smugmug.Service, folderID string) string {
+	t.Helper()
+	res, err := s.Nodes.Children(folderID).Context(context.Background()).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return names(res.ChildNodes)
+}
@@ -0,0 +1,89 @@
+package smugmug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PostGrant gives the person grant names access to the node and everything
+// below it; SmugMug emails them an invitation.
+func (r *NodesService) PostGrant(nodeID string, grant *Grant) *NodesPostGrantCall {
+	c := &NodesPostGrantCall{newCall(r.s, "POST", "node/"+nodeID+"!grants", "Grant", func(g *Grant) interface{} { return g })}
+	c.body = grant
+	return c
+}
+
+// DeleteGrant revokes the grant at grantURI, as found in Grant.URI.
+func (r *NodesService) DeleteGrant(grantURI string) *NodesDeleteGrantCall {
+	return &NodesDeleteGrantCall{newCall[struct{}](r.s, "DELETE", grantURI, "", nil)}
+}
+
+// NodeGrants returns all the grants on the node.
+func (r *NodesService) NodeGrants(ctx context.Context, nodeID string) ([]*Grant, error) {
+	return allPages[*Grant](ctx, r.Grants(nodeID).Call)
+}
+
+func (r *NodesService) AddGrant(ctx context.Context, nodeID string, grant *Grant) (*Grant, error) {
+	return r.PostGrant(nodeID, grant).Context(ctx).Do()
+}
+
+func (r *NodesService) RemoveGrant(ctx context.Context, grantURI string) error {
+	return r.DeleteGrant(grantURI).Context(ctx).Do()
+}
+
+// UserGrants returns the grants the user has issued on any of their nodes.
+func (r *UsersService) UserGrants(ctx context.Context, nickname string) ([]*Grant, error) {
+	return allPages[*Grant](ctx, r.Grants(nickname).Call)
+}
+
+type NodesPostGrantCall struct {
+	*Call[Grant]
+}
+
+func (c *NodesPostGrantCall) Context(ctx context.Context) *NodesPostGrantCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+func (c *NodesPostGrantCall) Do() (*Grant, error) {
+	if grant := c.body.(*Grant); grant == nil {
+		return nil, fmt.Errorf("grant is nil")
+	} else if grant.Email == "" {
+		return nil, fmt.Errorf("grant Email is empty")
+	}
+	return c.Call.Do()
+}
+
+type NodesDeleteGrantCall struct {
+	*Call[struct{}]
+}
+
+func (c *NodesDeleteGrantCall) Context(ctx context.Context) *NodesDeleteGrantCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+func (c *NodesDeleteGrantCall) Do() error {
+	_, err := c.Call.Do()
+	return err
+}
+
+// Grant gives one person access to a node, typically a private or
+// GrantAccess folder or album, and everything below it. The node is linked
+// from URIs as "Node".
+type Grant struct {
+	DateAdded *time.Time `json:",omitempty"`
+	Email     string     `json:",omitempty"`
+	Message   string     `json:",omitempty"` // sent with the invitation
+	Name      string     `json:",omitempty"` // display name of the grantee
+
+	ResponseLevel string `json:",omitempty"`
+	URI           string `json:"Uri,omitempty"`
+	URIs          *URIs  `json:"Uris,omitempty"`
+	WebURI        string `json:"WebUri,omitempty"`
+
+	Expansions Expansions                 `json:"-"`
+	Extra      map[string]json.RawMessage `json:"-"`
+}
@@ -0,0 +1,69 @@
+package smugmug_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestGrants(t *testing.T) {
+	fake, s, root := newFake(t)
+	smith := fake.AddAlbum(root, &smugmug.Album{Name: "Smith Wedding", Privacy: smugmug.PrivacyPrivate})
+	jones := fake.AddAlbum(root, &smugmug.Album{Name: "Jones Portraits", Privacy: smugmug.PrivacyPrivate})
+
+	ctx := context.Background()
+
+	g, err := s.Nodes.AddGrant(ctx, smith.NodeID, &smugmug.Grant{Email: "amy@example.com", Name: "Amy Smith", Message: "Your photos are ready"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.URI == "" || g.DateAdded == nil || g.Email != "amy@example.com" {
+		t.Errorf("created grant = %+v", g)
+	}
+	if _, err := s.Nodes.AddGrant(ctx, jones.NodeID, &smugmug.Grant{Email: "bo@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Nodes.AddGrant(ctx, smith.NodeID, &smugmug.Grant{Email: "AMY@example.com"})
+	var apiErr *smugmug.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusConflict {
+		t.Errorf("duplicate grant: err = %v", err)
+	}
+	if _, err := s.Nodes.AddGrant(ctx, smith.NodeID, &smugmug.Grant{Name: "No Address"}); err == nil {
+		t.Error("grant without Email: err = nil")
+	}
+
+	grants, err := s.Nodes.NodeGrants(ctx, smith.NodeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(grants) != 1 || grants[0].URI != g.URI {
+		t.Errorf("NodeGrants = %+v", grants)
+	}
+	all, err := s.Users.UserGrants(ctx, nickname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[0].Email != "amy@example.com" || all[1].Email != "bo@example.com" {
+		t.Errorf("UserGrants = %+v", all)
+	}
+	res, err := s.Users.Grants(nickname).Expand([]string{"Node"}).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, ok := smugmug.ExpansionOf[*smugmug.Node](res.UserGrants[0].Expansions, "Node"); !ok || n.NodeID != smith.NodeID {
+		t.Errorf("grant Node expansion = %+v", res.UserGrants[0].Expansions)
+	}
+
+	if err := s.Nodes.RemoveGrant(ctx, g.URI); err != nil {
+		t.Fatal(err)
+	}
+	if grants, err := s.Nodes.NodeGrants(ctx, smith.NodeID); err != nil || len(grants) != 0 {
+		t.Errorf("after revoke: %v, %v", grants, err)
+	}
+	if err := s.Nodes.RemoveGrant(ctx, g.URI); err == nil {
+		t.Error("revoking twice: err = nil")
+	}
+}
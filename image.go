@@ -1,10 +1,9 @@
 package smugmug
 
 import (
+	"context"
 	"encoding/json"
-	"net/http"
-	"net/url"
-	"strings"
+	"fmt"
 	"time"
 )
 
@@ -18,11 +17,39 @@ func NewImagesService(s *Service) *ImagesService {
 }
 
 func (r *ImagesService) Get(id string) *ImagesGetCall {
-	c := &ImagesGetCall{s: r.s, urlParams: url.Values{}}
-	c.id = id
+	return &ImagesGetCall{newCall(r.s, "GET", "image/"+id, "Image", func(res *ImagesGetResponse) interface{} { return &res.Image })}
+}
+
+func (r *ImagesService) GetImage(ctx context.Context, imageKey string, opts ...CallOption) (*ImagesGetResponse, error) {
+	c := r.Get(imageKey).Context(ctx)
+	applyOptions(c.urlParams, opts)
+	return c.Do()
+}
+
+// GetMany fetches many images in as few requests as possible, keyed by the
+// requested image key. Keys that fail are reported in a BatchErrors.
+func (r *ImagesService) GetMany(ctx context.Context, imageKeys ...string) (map[string]*Image, error) {
+	return getMany(ctx, r.s, "image", imageKeys, func(i *Image) string { return i.ImageKey })
+}
+
+func (r *ImagesService) Patch(imageKey string, patch *ImagePatch) *ImagesPatchCall {
+	c := &ImagesPatchCall{newCall(r.s, "PATCH", "image/"+imageKey, "Image", func(img *Image) interface{} { return img })}
+	c.body = patch
 	return c
 }
 
+func (r *ImagesService) Delete(imageKey string) *ImagesDeleteCall {
+	return &ImagesDeleteCall{newCall[struct{}](r.s, "DELETE", "image/"+imageKey, "", nil)}
+}
+
+func (r *ImagesService) PatchImage(ctx context.Context, imageKey string, patch *ImagePatch) (*Image, error) {
+	return r.Patch(imageKey, patch).Context(ctx).Do()
+}
+
+func (r *ImagesService) DeleteImage(ctx context.Context, imageKey string) error {
+	return r.Delete(imageKey).Context(ctx).Do()
+}
+
 type ImagesServiceResponse struct {
 	Code     int
 	Message  string
@@ -34,90 +61,86 @@ type ImagesServiceResponse struct {
 }
 
 type ImagesGetCall struct {
-	id string
-
-	s         *Service
-	urlParams url.Values
+	*Call[ImagesGetResponse]
 }
 
 func (c *ImagesGetCall) Expand(expansions []string) *ImagesGetCall {
-	c.urlParams.Set("_expand", strings.Join(expansions, ","))
+	c.Call.Expand(expansions)
 	return c
 }
 
 func (c *ImagesGetCall) Filter(filter []string) *ImagesGetCall {
-	c.urlParams.Set("_filter", strings.Join(filter, ","))
+	c.Call.Filter(filter)
 	return c
 }
 
-func (c *ImagesGetCall) doRequest() (*http.Response, error) {
-	urls := resolveRelative(c.s.BasePath, "image/"+c.id)
-	urls += "?" + encodeURLParams(c.urlParams)
-	req, _ := http.NewRequest("GET", urls, nil)
-	c.s.setHeaders(req)
-	debugRequest(req)
-	return c.s.client.Do(req)
+func (c *ImagesGetCall) Fields(fields ...ImageField) *ImagesGetCall {
+	c.Call.Filter(fieldNames(fields))
+	return c
 }
 
-func (c *ImagesGetCall) Do() (*ImagesGetResponse, error) {
-	res, err := c.doRequest()
-	if err != nil {
-		return nil, err
-	}
-	debugResponse(res)
-	defer closeBody(res)
-	if err := checkResponse(res); err != nil {
-		return nil, err
-	}
-	imagesRes := &ImagesServiceResponse{}
-	if err := json.NewDecoder(res.Body).Decode(&imagesRes); err != nil {
-		return nil, err
-	}
-	image := &Image{}
-	if err := json.Unmarshal(*imagesRes.Response.Image, &image); err != nil {
-		return nil, err
-	}
-	exp, err := unmarshallExpansions(image.URIs, imagesRes.Expansions)
-	if err != nil {
-		return nil, err
-	}
-	ret := &ImagesGetResponse{
-		Image: image,
-		ServerResponse: ServerResponse{
-			Header:         res.Header,
-			HTTPStatusCode: res.StatusCode,
-		},
-	}
-	_ = exp
-	for name, v := range exp {
-		switch name {
-		case "ImageAlbum":
-			ret.ImageAlbum = v.(*Album)
-		case "ImageDownload":
-			ret.ImageDownload = v.(*ImageDownload)
-		case "ImageMetadata":
-			ret.ImageMetadata = v.(*ImageMetadata)
-		case "ImageOwner":
-			ret.ImageOwner = v.(*User)
-		case "ImagePrices":
-			ret.ImagePrices = v.([]*CatalogSkuPrice)
-		case "ImageSizeDetails":
-			ret.ImageSizeDetails = v.(*ImageSizeDetails)
-		case "ImageSizes":
-			ret.ImageSizes = v.(*ImageSizes)
-		case "LargestImage":
-			ret.LargestImage = v.(*LargestImage)
-		}
+func (c *ImagesGetCall) FilterURIs(names ...string) *ImagesGetCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
 
+func (c *ImagesGetCall) ExpandTree(expansions ...*Expansion) *ImagesGetCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *ImagesGetCall) Context(ctx context.Context) *ImagesGetCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// ImagePatch holds the image fields Patch can change. Nil fields are left
+// untouched; use String, Bool, Int and Float64 to fill them in.
+type ImagePatch struct {
+	Title     *string  `json:",omitempty"`
+	Caption   *string  `json:",omitempty"`
+	Keywords  *string  `json:",omitempty"`
+	Hidden    *bool    `json:",omitempty"`
+	Latitude  *float64 `json:",omitempty"`
+	Longitude *float64 `json:",omitempty"`
+	Altitude  *int     `json:",omitempty"`
+}
+
+type ImagesPatchCall struct {
+	*Call[Image]
+}
+
+func (c *ImagesPatchCall) Context(ctx context.Context) *ImagesPatchCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+func (c *ImagesPatchCall) Do() (*Image, error) {
+	if c.body.(*ImagePatch) == nil {
+		return nil, fmt.Errorf("patch is nil")
 	}
-	return ret, nil
+	return c.Call.Do()
+}
+
+type ImagesDeleteCall struct {
+	*Call[struct{}]
+}
+
+func (c *ImagesDeleteCall) Context(ctx context.Context) *ImagesDeleteCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+func (c *ImagesDeleteCall) Do() error {
+	_, err := c.Call.Do()
+	return err
 }
 
 type ImagesGetResponse struct {
 	Image *Image
 
-	ImageAlbum *Album
-	// ImageComments
+	ImageAlbum       *Album
+	ImageComments    []*Comment
 	ImageDownload    *ImageDownload
 	ImageMetadata    *ImageMetadata
 	ImageOwner       *User
@@ -126,6 +149,9 @@ type ImagesGetResponse struct {
 	ImageSizes       *ImageSizes
 	LargestImage     *LargestImage
 
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
 	ServerResponse `json:"-"`
 }
 
@@ -149,8 +175,8 @@ type Image struct {
 	KeywordArray    []string         `json:",omitempty"`
 	Keywords        string           `json:",omitempty"`
 	LastUpdated     *time.Time       `json:",omitempty"`
-	Latitude        string           `json:",omitempty"`
-	Longitude       string           `json:",omitempty"`
+	Latitude        *Coordinate      `json:",omitempty"`
+	Longitude       *Coordinate      `json:",omitempty"`
 	OriginalHeight  int              `json:",omitempty"`
 	OriginalSize    int              `json:",omitempty"`
 	OriginalWidth   int              `json:",omitempty"`
@@ -165,6 +191,9 @@ type Image struct {
 	URI           string `json:"Uri,omitempty"`
 	URIs          *URIs  `json:"Uris,omitempty"`
 	WebURI        string `json:"WebUri,omitempty"`
+
+	Expansions Expansions                 `json:"-"`
+	Extra      map[string]json.RawMessage `json:"-"`
 }
 
 type ImageDownload struct {
@@ -175,85 +204,87 @@ type ImageDownload struct {
 }
 
 type ImageMetadata struct {
-	Altitude               string  `json:",omitempty"`
-	AltitudeReference      string  `json:",omitempty"`
-	Aperture               float32 `json:",omitempty"`
-	AudioCodec             string  `json:",omitempty"`
-	Author                 string  `json:",omitempty"`
-	AuthorTitle            string  `json:",omitempty"`
-	Brightness             string  `json:",omitempty"`
-	Caption                string  `json:",omitempty"`
-	Category               string  `json:",omitempty"`
-	CircleOfConfusion      string  `json:",omitempty"`
-	City                   string  `json:",omitempty"`
-	ColorSpace             string  `json:",omitempty"`
-	CompressedBitsPerPixel string  `json:",omitempty"`
-	Contrast               string  `json:",omitempty"`
-	Copyright              string  `json:",omitempty"`
-	CopyrightFlag          string  `json:",omitempty"`
-	CopyrightURL           string  `json:"CopyrightUrl"`
-	Country                string  `json:",omitempty"`
-	CountryCode            string  `json:",omitempty"`
-	CreatorContactInfo     string  `json:",omitempty"`
-	Credit                 string  `json:",omitempty"`
-	DateCreated            string  `json:",omitempty"` // *time.Time
-	DateDigitized          string  `json:",omitempty"` // *time.Time
-	DateTimeCreated        string  `json:",omitempty"` // *time.Time
-	DateTimeModified       string  `json:",omitempty"` // *time.Time
-	DepthOfField           string  `json:",omitempty"`
-	DigitalZoomRatio       float32 `json:",omitempty"`
-	Duration               string  `json:",omitempty"`
-	Exposure               string  `json:",omitempty"`
-	ExposureCompensation   string  `json:",omitempty"`
-	ExposureMode           string  `json:",omitempty"`
-	ExposureProgram        string  `json:",omitempty"`
-	FieldOfView            string  `json:",omitempty"`
-	Flash                  string  `json:",omitempty"`
-	FocalLength            string  `json:",omitempty"`
-	FocalLength35mm        string  `json:",omitempty"`
-	GainControl            string  `json:",omitempty"`
-	Headline               string  `json:",omitempty"`
-	HyperfocalDistance     string  `json:",omitempty"`
-	ISO                    int     `json:",omitempty"`
-	Keywords               string  `json:",omitempty"`
-	Latitude               float32 `json:",omitempty"`
-	LatitudeReference      string  `json:",omitempty"`
-	Lens                   string  `json:",omitempty"`
-	LensSerialNumber       string  `json:",omitempty"`
-	LightSource            string  `json:",omitempty"`
-	Longitude              float32 `json:",omitempty"`
-	LongitudeReference     string  `json:",omitempty"`
-	Make                   string  `json:",omitempty"`
-	Metering               string  `json:",omitempty"`
-	MicroDateTimeCreated   string  `json:",omitempty"` // *time.Time
-	MicroDateTimeDigitized string  `json:",omitempty"` // *time.Time
-	Model                  string  `json:",omitempty"`
-	NormalizedLightValue   float32 `json:",omitempty"`
-	Rating                 string  `json:",omitempty"`
-	Saturation             string  `json:",omitempty"`
-	ScaleFactor            string  `json:",omitempty"`
-	SceneCaptureType       string  `json:",omitempty"`
-	SensingMethod          string  `json:",omitempty"`
-	SerialNumber           string  `json:",omitempty"`
-	Sharpness              string  `json:",omitempty"`
-	Software               string  `json:",omitempty"`
-	Source                 string  `json:",omitempty"`
-	SpecialInstructions    string  `json:",omitempty"`
-	State                  string  `json:",omitempty"`
-	SubjectDistance        string  `json:",omitempty"`
-	SubjectRange           string  `json:",omitempty"`
-	SupplementalCategories string  `json:",omitempty"`
-	TimeCreated            string  `json:",omitempty"`
-	Title                  string  `json:",omitempty"`
-	TransmissionReference  string  `json:",omitempty"`
-	UserComment            string  `json:",omitempty"`
-	VideoCodec             string  `json:",omitempty"`
-	WhiteBalance           string  `json:",omitempty"`
-	WriterEditor           string  `json:",omitempty"`
+	Altitude               string        `json:",omitempty"`
+	AltitudeReference      string        `json:",omitempty"`
+	Aperture               float32       `json:",omitempty"`
+	AudioCodec             string        `json:",omitempty"`
+	Author                 string        `json:",omitempty"`
+	AuthorTitle            string        `json:",omitempty"`
+	Brightness             string        `json:",omitempty"`
+	Caption                string        `json:",omitempty"`
+	Category               string        `json:",omitempty"`
+	CircleOfConfusion      string        `json:",omitempty"`
+	City                   string        `json:",omitempty"`
+	ColorSpace             string        `json:",omitempty"`
+	CompressedBitsPerPixel string        `json:",omitempty"`
+	Contrast               string        `json:",omitempty"`
+	Copyright              string        `json:",omitempty"`
+	CopyrightFlag          string        `json:",omitempty"`
+	CopyrightURL           string        `json:"CopyrightUrl"`
+	Country                string        `json:",omitempty"`
+	CountryCode            string        `json:",omitempty"`
+	CreatorContactInfo     string        `json:",omitempty"`
+	Credit                 string        `json:",omitempty"`
+	DateCreated            *Timestamp    `json:",omitempty"`
+	DateDigitized          *Timestamp    `json:",omitempty"`
+	DateTimeCreated        *Timestamp    `json:",omitempty"`
+	DateTimeModified       *Timestamp    `json:",omitempty"`
+	DepthOfField           string        `json:",omitempty"`
+	DigitalZoomRatio       float32       `json:",omitempty"`
+	Duration               string        `json:",omitempty"`
+	Exposure               *ExposureTime `json:",omitempty"`
+	ExposureCompensation   string        `json:",omitempty"`
+	ExposureMode           string        `json:",omitempty"`
+	ExposureProgram        string        `json:",omitempty"`
+	FieldOfView            string        `json:",omitempty"`
+	Flash                  string        `json:",omitempty"`
+	FocalLength            *FocalLength  `json:",omitempty"`
+	FocalLength35mm        *FocalLength  `json:",omitempty"`
+	GainControl            string        `json:",omitempty"`
+	Headline               string        `json:",omitempty"`
+	HyperfocalDistance     string        `json:",omitempty"`
+	ISO                    int           `json:",omitempty"`
+	Keywords               string        `json:",omitempty"`
+	Latitude               *Coordinate   `json:",omitempty"`
+	LatitudeReference      string        `json:",omitempty"`
+	Lens                   string        `json:",omitempty"`
+	LensSerialNumber       string        `json:",omitempty"`
+	LightSource            string        `json:",omitempty"`
+	Longitude              *Coordinate   `json:",omitempty"`
+	LongitudeReference     string        `json:",omitempty"`
+	Make                   string        `json:",omitempty"`
+	Metering               string        `json:",omitempty"`
+	MicroDateTimeCreated   *Timestamp    `json:",omitempty"`
+	MicroDateTimeDigitized *Timestamp    `json:",omitempty"`
+	Model                  string        `json:",omitempty"`
+	NormalizedLightValue   float32       `json:",omitempty"`
+	Rating                 string        `json:",omitempty"`
+	Saturation             string        `json:",omitempty"`
+	ScaleFactor            string        `json:",omitempty"`
+	SceneCaptureType       string        `json:",omitempty"`
+	SensingMethod          string        `json:",omitempty"`
+	SerialNumber           string        `json:",omitempty"`
+	Sharpness              string        `json:",omitempty"`
+	Software               string        `json:",omitempty"`
+	Source                 string        `json:",omitempty"`
+	SpecialInstructions    string        `json:",omitempty"`
+	State                  string        `json:",omitempty"`
+	SubjectDistance        string        `json:",omitempty"`
+	SubjectRange           string        `json:",omitempty"`
+	SupplementalCategories string        `json:",omitempty"`
+	TimeCreated            string        `json:",omitempty"`
+	Title                  string        `json:",omitempty"`
+	TransmissionReference  string        `json:",omitempty"`
+	UserComment            string        `json:",omitempty"`
+	VideoCodec             string        `json:",omitempty"`
+	WhiteBalance           string        `json:",omitempty"`
+	WriterEditor           string        `json:",omitempty"`
 
 	ResponseLevel  string `json:",omitempty"`
 	URI            string `json:"Uri,omitempty"`
 	URIDescription string `json:"UriDescription,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 type CatalogSkuPrice struct {
@@ -0,0 +1,50 @@
+package smugmug
+
+import (
+	"testing"
+)
+
+func TestImagesGet(t *testing.T) {
+	s := newTestService(t)
+	res, err := s.Images.Get("SD5BL92-1").Expand([]string{
+		"ImageAlbum",
+		"ImageDownload",
+		"ImageMetadata",
+		"ImageOwner",
+		"ImagePrices",
+		"ImageSizeDetails",
+		"ImageSizes",
+		"LargestImage",
+	}).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := res.Image
+	if i.ImageKey != "SD5BL92" || i.FileName != "DSC_0042.jpg" || i.OriginalWidth != 6000 || len(i.KeywordArray) != 3 {
+		t.Errorf("Image = %+v", i)
+	}
+	if res.ImageAlbum == nil || res.ImageAlbum.AlbumKey != "kQ3t8P" {
+		t.Errorf("ImageAlbum = %+v", res.ImageAlbum)
+	}
+	if res.ImageDownload == nil || res.ImageDownload.URL == "" {
+		t.Errorf("ImageDownload = %+v", res.ImageDownload)
+	}
+	if m := res.ImageMetadata; m == nil || m.Model != "NIKON D7100" || m.ISO != 200 || m.SerialNumber != "REDACTED" {
+		t.Errorf("ImageMetadata = %+v", m)
+	}
+	if res.ImageOwner == nil || res.ImageOwner.NickName != "cmac" {
+		t.Errorf("ImageOwner = %+v", res.ImageOwner)
+	}
+	if len(res.ImagePrices) != 2 || res.ImagePrices[1].Price != 24.99 {
+		t.Errorf("ImagePrices = %+v", res.ImagePrices)
+	}
+	if d := res.ImageSizeDetails; d == nil || d.ImageSizeOriginal.Width != 6000 || len(d.UsableSizes) != 9 {
+		t.Errorf("ImageSizeDetails = %+v", d)
+	}
+	if res.ImageSizes == nil || res.ImageSizes.LargestImageURL != res.ImageSizes.OriginalImageURL {
+		t.Errorf("ImageSizes = %+v", res.ImageSizes)
+	}
+	if l := res.LargestImage; l == nil || l.Height != 4000 || !l.Usable {
+		t.Errorf("LargestImage = %+v", l)
+	}
+}
@@ -0,0 +1,67 @@
+package smugmug_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestImageEdits(t *testing.T) {
+	fake, s, root := newFake(t)
+	paris := fake.AddAlbum(root, &smugmug.Album{Name: "Paris"})
+	rome := fake.AddAlbum(root, &smugmug.Album{Name: "Rome"})
+	var keys []string
+	for _, name := range []string{"a.jpg", "b.jpg", "c.jpg", "d.jpg"} {
+		keys = append(keys, fake.AddImage(paris.AlbumKey, &smugmug.Image{FileName: name, Hidden: true}, []byte(name)).ImageKey)
+	}
+
+	ctx := context.Background()
+
+	img, err := s.Images.PatchImage(ctx, keys[0], &smugmug.ImagePatch{
+		Title:    smugmug.String("Eiffel Tower"),
+		Hidden:   smugmug.Bool(false),
+		Latitude: smugmug.Float64(48.85837),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Title != "Eiffel Tower" || img.Hidden || img.Latitude == nil || img.Latitude.Degrees != 48.85837 {
+		t.Errorf("PatchImage = %+v", img)
+	}
+	if img.FileName != "a.jpg" {
+		t.Errorf("PatchImage changed FileName to %q", img.FileName)
+	}
+
+	err = s.Albums.MoveAlbumImages(ctx, rome.AlbumKey,
+		smugmug.AlbumImageURI(paris.AlbumKey, keys[1]),
+		smugmug.AlbumImageURI(paris.AlbumKey, keys[2]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Albums.CollectAlbumImages(ctx, rome.AlbumKey, smugmug.AlbumImageURI(paris.AlbumKey, keys[3])); err != nil {
+		t.Fatal(err)
+	}
+	if got := albumNames(t, s, paris.AlbumKey); got != "a.jpg, d.jpg" {
+		t.Errorf("Paris = %s", got)
+	}
+	if got := albumNames(t, s, rome.AlbumKey); got != "b.jpg, c.jpg, d.jpg" {
+		t.Errorf("Rome = %s", got)
+	}
+
+	if err := s.Albums.DeleteAlbumImages(ctx, rome.AlbumKey, smugmug.AlbumImageURI(rome.AlbumKey, keys[3])); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Images.DeleteImage(ctx, keys[0]); err != nil {
+		t.Fatal(err)
+	}
+	if got := albumNames(t, s, paris.AlbumKey); got != "d.jpg" {
+		t.Errorf("Paris after delete = %s", got)
+	}
+	if _, err := s.Images.GetImage(ctx, keys[0]); err == nil {
+		t.Error("deleted image is still there")
+	}
+	if err := s.Albums.MoveAlbumImages(ctx, rome.AlbumKey); err == nil {
+		t.Error("expected error for empty move")
+	}
+}
@@ -0,0 +1,396 @@
+// Command endpointgen writes call builders, response types and expansion
+// decoders for package smugmug from saved endpoint descriptions.
+//
+// Each file in the input directory is the body of an OPTIONS request against
+// one endpoint, trimmed to the Options and Response objects, plus an
+// optional "Go" object overriding the names the generator derives:
+//
+//	{
+//	  "Go": {"Service": "Albums", "Method": "Images", "Model": "Image"},
+//	  "Options": {"Methods": [...], "Path": [...], "Parameters": {"GET": [...]}},
+//	  "Response": {"Locator": "AlbumImage", "LocatorType": "Objects", "EndpointType": "AlbumImages"}
+//	}
+//
+// Its "Params" object gives parameters that take one of the package's enums
+// that type instead of string, e.g. {"SortMethod": "SearchSortMethod"}.
+//
+// Usage:
+//
+//	go run ./internal/endpointgen -o endpoints_gen.go endpoints
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+var out = flag.String("o", "endpoints_gen.go", "output file")
+
+type description struct {
+	Go struct {
+		Service string            // e.g. Albums; derived from the first path segment
+		Method  string            // e.g. Images; derived from EndpointType
+		Model   string            // e.g. Image; defaults to Locator
+		Field   string            // response field; defaults to EndpointType
+		Params  map[string]string // parameter name to Go string type
+	}
+	Options struct {
+		Methods []string
+		Path    []struct {
+			Type      string `json:"type"`
+			Text      string `json:"text"`
+			ParamName string `json:"param_name"`
+		}
+		Parameters map[string][]parameter
+	}
+	Response struct {
+		Locator      string
+		LocatorType  string
+		EndpointType string
+	}
+}
+
+type parameter struct {
+	Name        string
+	Type        string
+	Description string
+}
+
+type endpoint struct {
+	File       string
+	Service    string
+	Method     string
+	Call       string
+	Response   string
+	Model      string
+	Field      string
+	Locator    string
+	Expansion  string
+	Collection bool
+	Fields     bool // model has a generated <Model>Field type
+	Args       []string
+	Path       string // Go expression for the path relative to BasePath
+	Params     []param
+}
+
+type param struct {
+	Name   string // API name
+	Setter string
+	GoType string
+	Value  string // Go expression formatting v
+	Doc    string
+}
+
+// services maps the first path segment to the service that owns it.
+var services = map[string]string{
+	"album": "Albums",
+	"image": "Images",
+	"node":  "Nodes",
+	"user":  "Users",
+}
+
+// argNames gives Go names for path parameters.
+var argNames = map[string]string{
+	"albumkey": "albumKey",
+	"imagekey": "imageKey",
+	"nodeid":   "nodeID",
+	"nickname": "nickname",
+}
+
+// reserved are method names every generated call already has.
+var reserved = map[string]bool{
+	"Context": true, "Do": true, "Expand": true, "ExpandTree": true,
+	"Fields": true, "Filter": true, "FilterURIs": true, "Pages": true,
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("endpointgen: ")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		log.Fatal("usage: endpointgen [-o file] dir")
+	}
+	paths, err := filepath.Glob(filepath.Join(flag.Arg(0), "*.json"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fieldTypes := generatedFieldTypes()
+
+	var endpoints []*endpoint
+	for _, path := range paths {
+		e, err := load(path)
+		if err != nil {
+			log.Fatalf("%s: %v", path, err)
+		}
+		e.Fields = fieldTypes[e.Model]
+		endpoints = append(endpoints, e)
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Call < endpoints[j].Call })
+
+	imports := map[string]bool{"context": true, "encoding/json": true}
+	for _, e := range endpoints {
+		for _, p := range e.Params {
+			switch p.GoType {
+			case "int", "bool":
+				imports["strconv"] = true
+			case "time.Time":
+				imports["time"] = true
+			case "[]string":
+				imports["strings"] = true
+			}
+		}
+	}
+	var pkgs []string
+	for p := range imports {
+		pkgs = append(pkgs, p)
+	}
+	sort.Strings(pkgs)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]interface{}{"Imports": pkgs, "Endpoints": endpoints}); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		os.Stdout.Write(buf.Bytes())
+		log.Fatalf("formatting output: %v", err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func load(path string) (*endpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var d description
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	if !contains(d.Options.Methods, "GET") {
+		return nil, fmt.Errorf("no GET method")
+	}
+	e := &endpoint{
+		File:       filepath.Base(path),
+		Service:    d.Go.Service,
+		Method:     d.Go.Method,
+		Model:      d.Go.Model,
+		Field:      d.Go.Field,
+		Locator:    d.Response.Locator,
+		Expansion:  d.Response.EndpointType,
+		Collection: d.Response.LocatorType == "Objects",
+	}
+	if e.Locator == "" || e.Expansion == "" {
+		return nil, fmt.Errorf("missing Locator or EndpointType")
+	}
+
+	var path_ []string
+	for i, part := range d.Options.Path {
+		switch part.Type {
+		case "path":
+			text := part.Text
+			if i == 0 {
+				text = strings.TrimPrefix(text, "/api/v2/")
+				if e.Service == "" {
+					e.Service = services[strings.Trim(strings.SplitN(text, "/", 2)[0], "!")]
+				}
+			}
+			if text != "" {
+				path_ = append(path_, fmt.Sprintf("%q", text))
+			}
+		case "singleparam":
+			arg, ok := argNames[part.ParamName]
+			if !ok {
+				arg = strings.ToLower(part.ParamName)
+			}
+			e.Args = append(e.Args, arg)
+			path_ = append(path_, arg)
+		default:
+			return nil, fmt.Errorf("unsupported path part %q", part.Type)
+		}
+	}
+	if e.Service == "" {
+		return nil, fmt.Errorf("cannot derive service; set Go.Service")
+	}
+	e.Path = strings.Join(path_, " + ")
+
+	if e.Method == "" {
+		singular := strings.TrimSuffix(e.Service, "s")
+		e.Method = strings.TrimPrefix(e.Expansion, singular)
+		if e.Method == "" {
+			e.Method = e.Expansion
+		}
+	}
+	if e.Model == "" {
+		e.Model = e.Locator
+	}
+	if e.Field == "" {
+		e.Field = e.Expansion
+	}
+	e.Call = e.Service + e.Method + "Call"
+	e.Response = e.Service + e.Method + "Response"
+
+	for _, p := range d.Options.Parameters["GET"] {
+		if strings.HasPrefix(p.Name, "_") {
+			continue
+		}
+		gp := param{Name: p.Name, Setter: exported(p.Name), Doc: firstSentence(p.Description)}
+		if reserved[gp.Setter] {
+			return nil, fmt.Errorf("parameter %s collides with a call method", p.Name)
+		}
+		switch p.Type {
+		case "Integer":
+			gp.GoType, gp.Value = "int", "strconv.Itoa(v)"
+		case "Boolean":
+			gp.GoType, gp.Value = "bool", "strconv.FormatBool(v)"
+		case "DateTime":
+			gp.GoType, gp.Value = "time.Time", "v.Format(time.RFC3339)"
+		case "Array":
+			gp.GoType, gp.Value = "[]string", `strings.Join(v, ",")`
+		default:
+			gp.GoType, gp.Value = "string", "v"
+			if t := d.Go.Params[p.Name]; t != "" {
+				gp.GoType, gp.Value = t, "string(v)"
+			}
+		}
+		e.Params = append(e.Params, gp)
+	}
+	return e, nil
+}
+
+// generatedFieldTypes reports which models have a <Model>Field type in
+// fields_gen.go, so that their calls get a typed Fields method.
+func generatedFieldTypes() map[string]bool {
+	ret := map[string]bool{}
+	data, err := os.ReadFile("fields_gen.go")
+	if err != nil {
+		return ret
+	}
+	for _, m := range regexp.MustCompile(`(?m)^type (\w+)Field string$`).FindAllSubmatch(data, -1) {
+		ret[string(m[1])] = true
+	}
+	return ret
+}
+
+func exported(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '_' || r == '-' {
+			upper = true
+			continue
+		}
+		if upper {
+			b.WriteString(strings.ToUpper(string(r)))
+			upper = false
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func firstSentence(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if i := strings.Index(s, ". "); i >= 0 {
+		s = s[:i+1]
+	}
+	return s
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by internal/endpointgen; DO NOT EDIT.
+
+package smugmug
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+func init() {
+{{- range .Endpoints}}
+	expansionDecoders["{{.Expansion}}"] = {{if .Collection}}decodeObjects{{else}}decodeObject{{end}}[{{.Model}}]("{{.Locator}}")
+{{- end}}
+}
+{{range .Endpoints}}
+// {{.Method}} returns a call for the {{.Expansion}} endpoint, described in endpoints/{{.File}}.
+func (r *{{.Service}}Service) {{.Method}}({{range $i, $a := .Args}}{{if $i}}, {{end}}{{$a}}{{end}}{{if .Args}} string{{end}}) *{{.Call}} {
+	return &{{.Call}}{newCall(r.s, "GET", {{.Path}}, "{{.Locator}}", func(res *{{.Response}}) interface{} { return &res.{{.Field}} })}
+}
+
+type {{.Call}} struct {
+	*Call[{{.Response}}]
+}
+
+type {{.Response}} struct {
+	{{.Field}} {{if .Collection}}[]{{end}}*{{.Model}}
+{{- if .Collection}}
+	Pages *Pages
+{{- end}}
+
+	Other map[string]json.RawMessage ` + "`json:\",omitempty\"`" + `
+
+	Requested      FieldSet ` + "`json:\"-\"`" + `
+	ServerResponse ` + "`json:\"-\"`" + `
+}
+{{$c := .}}{{range .Params}}
+{{- if .Doc}}
+// {{.Setter}} sets the "{{.Name}}" parameter. {{.Doc}}
+{{- end}}
+func (c *{{$c.Call}}) {{.Setter}}(v {{.GoType}}) *{{$c.Call}} {
+	c.urlParams.Set("{{.Name}}", {{.Value}})
+	return c
+}
+{{end}}
+func (c *{{.Call}}) Expand(expansions []string) *{{.Call}} {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *{{.Call}}) Filter(filter []string) *{{.Call}} {
+	c.Call.Filter(filter)
+	return c
+}
+{{if .Fields}}
+func (c *{{.Call}}) Fields(fields ...{{.Model}}Field) *{{.Call}} {
+	c.Call.Filter(fieldNames(fields))
+	return c
+}
+{{end}}
+func (c *{{.Call}}) FilterURIs(names ...string) *{{.Call}} {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *{{.Call}}) ExpandTree(expansions ...*Expansion) *{{.Call}} {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *{{.Call}}) Context(ctx context.Context) *{{.Call}} {
+	c.Call.Context(ctx)
+	return c
+}
+{{end}}`))
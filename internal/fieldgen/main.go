@@ -0,0 +1,91 @@
+// Command fieldgen writes typed field-name constants for the model structs
+// of package smugmug, for use with _filter.
+//
+//	go run ./internal/fieldgen -o fields_gen.go album.go image.go node.go user.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	out   = flag.String("o", "fields_gen.go", "output file")
+	types = flag.String("types", "Album,Image,Node,User", "comma-separated struct types")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("fieldgen: ")
+	flag.Parse()
+
+	wanted := map[string]*ast.StructType{}
+	for _, name := range strings.Split(*types, ",") {
+		wanted[name] = nil
+	}
+	pkg := ""
+	fset := token.NewFileSet()
+	for _, path := range flag.Args() {
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			log.Fatal(err)
+		}
+		pkg = file.Name.Name
+		ast.Inspect(file, func(n ast.Node) bool {
+			ts, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			if st, ok := ts.Type.(*ast.StructType); ok {
+				if _, ok := wanted[ts.Name.Name]; ok {
+					wanted[ts.Name.Name] = st
+				}
+			}
+			return false
+		})
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by internal/fieldgen; DO NOT EDIT.\n\npackage %s\n", pkg)
+	for _, name := range strings.Split(*types, ",") {
+		st := wanted[name]
+		if st == nil {
+			log.Fatalf("struct %s not found", name)
+		}
+		fmt.Fprintf(&buf, "\n// %sField names a field of %s for Fields and FieldSet.\n", name, name)
+		fmt.Fprintf(&buf, "type %sField string\n\nconst (\n", name)
+		for _, f := range st.Fields.List {
+			if len(f.Names) == 0 || !f.Names[0].IsExported() {
+				continue
+			}
+			jsonName := f.Names[0].Name
+			if f.Tag != nil {
+				tag, _ := strconv.Unquote(f.Tag.Value)
+				if n := strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]; n == "-" {
+					continue
+				} else if n != "" {
+					jsonName = n
+				}
+			}
+			fmt.Fprintf(&buf, "\t%sField%s %sField = %q\n", name, f.Names[0].Name, name, jsonName)
+		}
+		buf.WriteString(")\n")
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting output: %v", err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
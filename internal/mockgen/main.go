@@ -0,0 +1,246 @@
+// Command mockgen writes function-field mocks for the *API interfaces
+// declared in a source file of package smugmug.
+//
+//	go run ./internal/mockgen -src api.go -o smugmugmock/mocks.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	src     = flag.String("src", "api.go", "source file declaring the interfaces")
+	out     = flag.String("o", "smugmugmock/mocks.go", "output file")
+	pkgName = flag.String("pkg", "smugmugmock", "package name of the output")
+	pkgPath = flag.String("import", "github.com/pilwon/go-smugmug", "import path of the source package")
+)
+
+type generator struct {
+	fset    *token.FileSet
+	file    *ast.File
+	srcPkg  string
+	imports map[string]string // name -> path, for packages referenced by the mocks
+	buf     bytes.Buffer
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("mockgen: ")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, *src, nil, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+	g := &generator{fset: fset, file: file, srcPkg: file.Name.Name, imports: map[string]string{}}
+	g.imports[g.srcPkg] = *pkgPath
+
+	var body bytes.Buffer
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			it, ok := ts.Type.(*ast.InterfaceType)
+			if !ok || !ts.Name.IsExported() || !strings.HasSuffix(ts.Name.Name, "API") {
+				continue
+			}
+			g.mock(&body, ts.Name.Name, it)
+		}
+	}
+
+	g.buf.WriteString("// Code generated by internal/mockgen from " + *src + "; DO NOT EDIT.\n\n")
+	g.buf.WriteString("package " + *pkgName + "\n\nimport (\n")
+	var names []string
+	for name := range g.imports {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		pi, pj := g.imports[names[i]], g.imports[names[j]]
+		if isStd(pi) != isStd(pj) {
+			return isStd(pi)
+		}
+		return pi < pj
+	})
+	for i, name := range names {
+		path := g.imports[name]
+		if i > 0 && isStd(g.imports[names[i-1]]) && !isStd(path) {
+			g.buf.WriteString("\n")
+		}
+		if path[strings.LastIndex(path, "/")+1:] == name {
+			fmt.Fprintf(&g.buf, "\t%q\n", path)
+		} else {
+			fmt.Fprintf(&g.buf, "\t%s %q\n", name, path)
+		}
+	}
+	g.buf.WriteString(")\n")
+	g.buf.Write(body.Bytes())
+
+	formatted, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting output: %v\n%s", err, g.buf.Bytes())
+	}
+	if err := os.WriteFile(*out, formatted, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func (g *generator) mock(w *bytes.Buffer, name string, it *ast.InterfaceType) {
+	type method struct {
+		name    string
+		params  []string
+		args    []string
+		sig     string
+		results bool
+	}
+	var methods []method
+	for _, f := range it.Methods.List {
+		ft, ok := f.Type.(*ast.FuncType)
+		if !ok {
+			log.Fatalf("%s: embedded interfaces are not supported", name)
+		}
+		ft = g.qualify(ft).(*ast.FuncType)
+		m := method{name: f.Names[0].Name, results: ft.Results != nil && len(ft.Results.List) > 0}
+		for _, p := range ft.Params.List {
+			names := p.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{ast.NewIdent("p" + strconv.Itoa(len(m.args)))}
+			}
+			for _, n := range names {
+				typ := g.expr(p.Type)
+				m.params = append(m.params, n.Name+" "+typ)
+				if strings.HasPrefix(typ, "...") {
+					m.args = append(m.args, n.Name+"...")
+				} else {
+					m.args = append(m.args, n.Name)
+				}
+			}
+		}
+		m.sig = "(" + strings.Join(m.params, ", ") + ")"
+		if m.results {
+			m.sig += " " + g.expr(&ast.FuncType{Params: &ast.FieldList{}, Results: ft.Results})[len("func() "):]
+		}
+		methods = append(methods, m)
+	}
+
+	fmt.Fprintf(w, "\n// %s is a mock implementation of %s.%s. Each method calls the\n", name, g.srcPkg, name)
+	fmt.Fprintf(w, "// matching Func field, which must be set if the method is used.\n")
+	fmt.Fprintf(w, "type %s struct {\n\trecorder\n\n", name)
+	for _, m := range methods {
+		fmt.Fprintf(w, "\t%sFunc func%s\n", m.name, m.sig)
+	}
+	fmt.Fprintf(w, "}\n\nvar _ %s.%s = (*%s)(nil)\n", g.srcPkg, name, name)
+	for _, m := range methods {
+		recorded := make([]string, len(m.args))
+		for i, a := range m.args {
+			recorded[i] = strings.TrimSuffix(a, "...")
+		}
+		fmt.Fprintf(w, "\nfunc (m *%s) %s%s {\n", name, m.name, m.sig)
+		fmt.Fprintf(w, "\tm.record(%q%s)\n", m.name, prefixAll(", ", recorded))
+		fmt.Fprintf(w, "\tif m.%sFunc == nil {\n\t\tpanic(\"%s: %s.%s called without %sFunc\")\n\t}\n", m.name, *pkgName, name, m.name, m.name)
+		call := fmt.Sprintf("m.%sFunc(%s)", m.name, strings.Join(m.args, ", "))
+		if m.results {
+			fmt.Fprintf(w, "\treturn %s\n}\n", call)
+		} else {
+			fmt.Fprintf(w, "\t%s\n}\n", call)
+		}
+	}
+}
+
+// qualify rewrites identifiers declared in the source package as selectors
+// on that package and notes the imports the mock needs.
+func (g *generator) qualify(n ast.Node) ast.Node {
+	switch n := n.(type) {
+	case *ast.Ident:
+		if types.Universe.Lookup(n.Name) != nil {
+			return n
+		}
+		if !n.IsExported() {
+			log.Fatalf("unexported type %s cannot be used from package %s", n.Name, *pkgName)
+		}
+		return &ast.SelectorExpr{X: ast.NewIdent(g.srcPkg), Sel: n}
+	case *ast.SelectorExpr:
+		pkg := n.X.(*ast.Ident).Name
+		g.imports[pkg] = g.importPath(pkg)
+		return n
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: g.qualify(n.X).(ast.Expr)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: n.Len, Elt: g.qualify(n.Elt).(ast.Expr)}
+	case *ast.MapType:
+		return &ast.MapType{Key: g.qualify(n.Key).(ast.Expr), Value: g.qualify(n.Value).(ast.Expr)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: n.Dir, Value: g.qualify(n.Value).(ast.Expr)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: g.qualify(n.Elt).(ast.Expr)}
+	case *ast.IndexExpr:
+		return &ast.IndexExpr{X: g.qualify(n.X).(ast.Expr), Index: g.qualify(n.Index).(ast.Expr)}
+	case *ast.FuncType:
+		return &ast.FuncType{Params: g.qualifyFields(n.Params), Results: g.qualifyFields(n.Results)}
+	case *ast.InterfaceType:
+		return n
+	}
+	log.Fatalf("unsupported type expression %T", n)
+	return nil
+}
+
+func (g *generator) qualifyFields(fl *ast.FieldList) *ast.FieldList {
+	if fl == nil {
+		return nil
+	}
+	ret := &ast.FieldList{}
+	for _, f := range fl.List {
+		ret.List = append(ret.List, &ast.Field{Names: f.Names, Type: g.qualify(f.Type).(ast.Expr)})
+	}
+	return ret
+}
+
+func (g *generator) importPath(name string) string {
+	for _, imp := range g.file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		if imp.Name != nil && imp.Name.Name == name {
+			return path
+		}
+		if imp.Name == nil && path[strings.LastIndex(path, "/")+1:] == name {
+			return path
+		}
+	}
+	log.Fatalf("no import for package %s", name)
+	return ""
+}
+
+func (g *generator) expr(e ast.Node) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), e); err != nil {
+		log.Fatal(err)
+	}
+	return buf.String()
+}
+
+func prefixAll(prefix string, list []string) string {
+	var b strings.Builder
+	for _, s := range list {
+		b.WriteString(prefix + s)
+	}
+	return b.String()
+}
+
+func isStd(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
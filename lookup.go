@@ -0,0 +1,119 @@
+package smugmug
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// LookupPath returns a call resolving urlPath, a path below nickname's site
+// such as "/Travel/2015/Paris", to the node or album found there. Paths
+// follow Node.URLPath: a leading slash, no trailing slash, and "" or "/" for
+// the root folder.
+func (r *UsersService) LookupPath(nickname, urlPath string) *UsersLookupPathCall {
+	c := &UsersLookupPathCall{newCall(r.s, "GET", "user/"+nickname+"!urlpathlookup", "", func(res *UsersLookupPathResponse) interface{} {
+		switch res.Locator {
+		case "Album":
+			return &res.Album
+		case "Node":
+			return &res.Node
+		}
+		return nil
+	})}
+	c.urlParams.Set("urlpath", cleanURLPath(urlPath))
+	return c
+}
+
+func (r *UsersService) LookupUserPath(ctx context.Context, nickname, urlPath string, opts ...CallOption) (*UsersLookupPathResponse, error) {
+	c := r.LookupPath(nickname, urlPath).Context(ctx)
+	applyOptions(c.urlParams, opts)
+	return c.Do()
+}
+
+// ResolveWebURI returns the object a page of the SmugMug site shows, given
+// its full address as found in any object's WebURI.
+func (s *Service) ResolveWebURI(ctx context.Context, webURI string, opts ...CallOption) (*WebURIResponse, error) {
+	c := newCall(s, "GET", "!weburilookup", "", func(res *WebURIResponse) interface{} {
+		switch res.Locator {
+		case "Album":
+			return &res.Album
+		case "Image":
+			return &res.Image
+		case "Node":
+			return &res.Node
+		case "User":
+			return &res.User
+		}
+		return nil
+	})
+	c.urlParams.Set("WebUri", webURI)
+	applyOptions(c.urlParams, opts)
+	return c.Context(ctx).Do()
+}
+
+func cleanURLPath(p string) string {
+	p = strings.Trim(strings.TrimSpace(p), "/")
+	if p == "" {
+		return "/"
+	}
+	return "/" + p
+}
+
+type UsersLookupPathCall struct {
+	*Call[UsersLookupPathResponse]
+}
+
+func (c *UsersLookupPathCall) Expand(expansions []string) *UsersLookupPathCall {
+	c.Call.Expand(expansions)
+	return c
+}
+
+func (c *UsersLookupPathCall) Filter(filter []string) *UsersLookupPathCall {
+	c.Call.Filter(filter)
+	return c
+}
+
+func (c *UsersLookupPathCall) FilterURIs(names ...string) *UsersLookupPathCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *UsersLookupPathCall) ExpandTree(expansions ...*Expansion) *UsersLookupPathCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *UsersLookupPathCall) Context(ctx context.Context) *UsersLookupPathCall {
+	c.Call.Context(ctx)
+	return c
+}
+
+// UsersLookupPathResponse holds the Album or the Node, as named by Locator,
+// found at the path. Expanding "Node" on an album fills both.
+type UsersLookupPathResponse struct {
+	Locator string
+
+	Album *Album
+	Node  *Node
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
+
+// WebURIResponse holds the object, as named by Locator, that a web address
+// resolves to.
+type WebURIResponse struct {
+	Locator string
+
+	Album *Album
+	Image *Image
+	Node  *Node
+	User  *User
+
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
+	ServerResponse `json:"-"`
+}
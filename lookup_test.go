@@ -0,0 +1,71 @@
+package smugmug_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestLookupPath(t *testing.T) {
+	fake, s, root := newFake(t)
+	travel := fake.AddNode(root, &smugmug.Node{Name: "Travel", Type: smugmug.NodeTypeFolder})
+	year := fake.AddNode(travel.NodeID, &smugmug.Node{Name: "2015", Type: smugmug.NodeTypeFolder})
+	paris := fake.AddAlbum(year.NodeID, &smugmug.Album{Name: "Paris"})
+
+	ctx := context.Background()
+
+	res, err := s.Users.LookupPath(nickname, "/Travel/2015/").Context(ctx).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Locator != "Node" || res.Node == nil || res.Node.NodeID != year.NodeID || res.Album != nil {
+		t.Errorf("folder: got %s %+v", res.Locator, res.Node)
+	}
+
+	res, err = s.Users.LookupPath(nickname, "Travel/2015/Paris").Expand([]string{"Node"}).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Locator != "Album" || res.Album == nil || res.Album.AlbumKey != paris.AlbumKey {
+		t.Fatalf("album: got %s %+v", res.Locator, res.Album)
+	}
+	if res.Node == nil || res.Node.NodeID != paris.NodeID {
+		t.Errorf("album Node expansion = %+v", res.Node)
+	}
+
+	if _, err := s.Users.LookupPath(nickname, "/Travel/2016").Do(); err == nil {
+		t.Error("missing path: err = nil")
+	}
+
+	web, err := s.ResolveWebURI(ctx, res.Album.WebURI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if web.Locator != "Album" || web.Album.AlbumKey != paris.AlbumKey {
+		t.Errorf("album WebUri: got %s %+v", web.Locator, web.Album)
+	}
+	img := fake.AddImage(paris.AlbumKey, &smugmug.Image{FileName: "louvre.jpg"}, []byte("louvre"))
+	got, err := s.Images.GetImage(ctx, img.ImageKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	web, err = s.ResolveWebURI(ctx, got.Image.WebURI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if web.Locator != "Image" || web.Image.ImageKey != img.ImageKey {
+		t.Errorf("image WebUri: got %s %+v", web.Locator, web.Image)
+	}
+	user, err := s.Users.GetUser(ctx, nickname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	web, err = s.ResolveWebURI(ctx, user.User.WebURI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if web.Locator != "User" || web.User.NickName != nickname {
+		t.Errorf("user WebUri: got %s %+v", web.Locator, web.User)
+	}
+}
@@ -1,15 +1,18 @@
 package smugmug
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
 	"strings"
 	"time"
 )
 
+// errStop ends a Pages walk early.
+var errStop = errors.New("stop")
+
 type NodesService struct {
 	s *Service
 }
@@ -20,18 +23,131 @@ func NewNodesService(s *Service) *NodesService {
 }
 
 func (r *NodesService) Get(id string) *NodesGetCall {
-	c := &NodesGetCall{s: r.s, urlParams: url.Values{}}
-	c.id = id
-	return c
+	return &NodesGetCall{newCall(r.s, "GET", "node/"+id, "Node", func(res *NodesGetResponse) interface{} { return &res.Node })}
 }
 
 func (r *NodesService) Create(parentNodeID string, node *Node) *NodesCreateCall {
-	c := &NodesCreateCall{s: r.s, urlParams: url.Values{}}
-	c.parentNodeID = parentNodeID
-	c.node = node
+	c := &NodesCreateCall{
+		Call:         newCall(r.s, "POST", "node/"+parentNodeID+"!children", "Node", func(n *Node) interface{} { return n }),
+		parentNodeID: parentNodeID,
+		node:         node,
+	}
+	c.body = node
 	return c
 }
 
+func (r *NodesService) GetNode(ctx context.Context, nodeID string, opts ...CallOption) (*NodesGetResponse, error) {
+	c := r.Get(nodeID).Context(ctx)
+	applyOptions(c.urlParams, opts)
+	return c.Do()
+}
+
+// GetMany fetches many nodes in as few requests as possible, keyed by node
+// ID. IDs that fail are reported in a BatchErrors.
+func (r *NodesService) GetMany(ctx context.Context, nodeIDs ...string) (map[string]*Node, error) {
+	return getMany(ctx, r.s, "node", nodeIDs, func(n *Node) string { return n.NodeID })
+}
+
+func (r *NodesService) CreateNode(ctx context.Context, parentNodeID string, node *Node, opts ...CallOption) (*Node, error) {
+	c := r.Create(parentNodeID, node).Context(ctx)
+	applyOptions(c.urlParams, opts)
+	return c.Do()
+}
+
+// EnsurePath makes sure the slash-separated path of node names exists below
+// rootNodeID, like mkdir -p, and returns its last node. Every segment but
+// the last is a folder; the last has type leaf. Existing children are
+// reused when their UrlName matches the one derived from the segment.
+// Missing folders on the way are created with just their name; a missing
+// last node also takes the settings of defaults, which may be nil or a
+// fetched node. A create that conflicts with a node made concurrently
+// reuses that node.
+func (r *NodesService) EnsurePath(ctx context.Context, rootNodeID, path string, leaf NodeType, defaults *Node) (*Node, error) {
+	var names []string
+	for _, name := range strings.Split(path, "/") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("path %q names no nodes", path)
+	}
+	parentID := rootNodeID
+	var n *Node
+	for i, name := range names {
+		typ, settings := NodeTypeFolder, (*Node)(nil)
+		if i == len(names)-1 {
+			typ, settings = leaf, defaults
+		}
+		var err error
+		if n, err = r.ensureChild(ctx, parentID, name, typ, settings); err != nil {
+			return nil, err
+		}
+		parentID = n.NodeID
+	}
+	return n, nil
+}
+
+func (r *NodesService) ensureChild(ctx context.Context, parentID, name string, typ NodeType, defaults *Node) (*Node, error) {
+	slug := URLName(name)
+	if n, err := r.findChild(ctx, parentID, slug, typ); n != nil || err != nil {
+		return n, err
+	}
+	node := nodeSettings(defaults)
+	node.Name, node.Type, node.URLName = name, typ, slug
+	n, err := r.CreateNode(ctx, parentID, node)
+	if apiErr, ok := err.(*APIError); ok && apiErr.Code == http.StatusConflict {
+		if n, err := r.findChild(ctx, parentID, slug, typ); n != nil || err != nil {
+			return n, err
+		}
+	}
+	return n, err
+}
+
+// nodeSettings copies the fields of defaults a new node can be created with,
+// leaving out its identity and whatever the server derives.
+func nodeSettings(defaults *Node) *Node {
+	if defaults == nil {
+		return &Node{}
+	}
+	return &Node{
+		Description:       defaults.Description,
+		HideOwner:         defaults.HideOwner,
+		HighlightImageURI: defaults.HighlightImageURI,
+		Keywords:          defaults.Keywords,
+		Password:          defaults.Password,
+		PasswordHint:      defaults.PasswordHint,
+		Privacy:           defaults.Privacy,
+		SecurityType:      defaults.SecurityType,
+		SmugSearchable:    defaults.SmugSearchable,
+		SortDirection:     defaults.SortDirection,
+		SortMethod:        defaults.SortMethod,
+		WorldSearchable:   defaults.WorldSearchable,
+	}
+}
+
+// findChild returns the child of parentID with the given UrlName, or nil if
+// there is none. A child of a type other than typ is an error.
+func (r *NodesService) findChild(ctx context.Context, parentID, slug string, typ NodeType) (*Node, error) {
+	var found *Node
+	err := r.Children(parentID).Pages(ctx, func(res *NodesChildrenResponse) error {
+		for _, child := range res.ChildNodes {
+			if strings.EqualFold(child.URLName, slug) {
+				found = child
+				return errStop
+			}
+		}
+		return nil
+	})
+	if err != nil && err != errStop {
+		return nil, err
+	}
+	if found != nil && found.Type != typ {
+		return nil, fmt.Errorf("%s exists as a %s, not a %s", found.URLPath, found.Type, typ)
+	}
+	return found, nil
+}
+
 type NodesServiceResponse struct {
 	Code     int
 	Message  string
@@ -43,77 +159,37 @@ type NodesServiceResponse struct {
 }
 
 type NodesGetCall struct {
-	id string
-
-	s         *Service
-	urlParams url.Values
+	*Call[NodesGetResponse]
 }
 
 func (c *NodesGetCall) Expand(expansions []string) *NodesGetCall {
-	c.urlParams.Set("_expand", strings.Join(expansions, ","))
+	c.Call.Expand(expansions)
 	return c
 }
 
 func (c *NodesGetCall) Filter(filter []string) *NodesGetCall {
-	c.urlParams.Set("_filter", strings.Join(filter, ","))
+	c.Call.Filter(filter)
 	return c
 }
 
-func (c *NodesGetCall) doRequest() (*http.Response, error) {
-	urls := resolveRelative(c.s.BasePath, "node/"+c.id)
-	urls += "?" + encodeURLParams(c.urlParams)
-	req, _ := http.NewRequest("GET", urls, nil)
-	c.s.setHeaders(req)
-	debugRequest(req)
-	return c.s.client.Do(req)
+func (c *NodesGetCall) Fields(fields ...NodeField) *NodesGetCall {
+	c.Call.Filter(fieldNames(fields))
+	return c
 }
 
-func (c *NodesGetCall) Do() (*NodesGetResponse, error) {
-	res, err := c.doRequest()
-	if err != nil {
-		return nil, err
-	}
-	debugResponse(res)
-	defer closeBody(res)
-	if err := checkResponse(res); err != nil {
-		return nil, err
-	}
-	nodesRes := &NodesServiceResponse{}
-	if err := json.NewDecoder(res.Body).Decode(&nodesRes); err != nil {
-		return nil, err
-	}
-	node := &Node{}
-	if err := json.Unmarshal(*nodesRes.Response.Node, &node); err != nil {
-		return nil, err
-	}
-	exp, err := unmarshallExpansions(node.URIs, nodesRes.Expansions)
-	if err != nil {
-		return nil, err
-	}
-	ret := &NodesGetResponse{
-		Node: node,
-		ServerResponse: ServerResponse{
-			Header:         res.Header,
-			HTTPStatusCode: res.StatusCode,
-		},
-	}
-	for name, v := range exp {
-		switch name {
-		case "Album":
-			ret.Album = v.(*Album)
-		case "ChildNodes":
-			ret.ChildNodes = v.([]*Node)
-		case "HighlightImage":
-			ret.HighlightImage = v.(*Image)
-		case "ParentNode":
-			ret.ParentNode = v.(*Node)
-		case "ParentNodes":
-			ret.ParentNodes = v.([]*Node)
-		case "User":
-			ret.User = v.(*User)
-		}
-	}
-	return ret, nil
+func (c *NodesGetCall) FilterURIs(names ...string) *NodesGetCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *NodesGetCall) ExpandTree(expansions ...*Expansion) *NodesGetCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *NodesGetCall) Context(ctx context.Context) *NodesGetCall {
+	c.Call.Context(ctx)
+	return c
 }
 
 type NodesGetResponse struct {
@@ -124,69 +200,54 @@ type NodesGetResponse struct {
 	// FolderByID *FolderByID // Deprecated
 	HighlightImage *Image
 	// MoveNodes      *MoveNodes
-	// NodeGrants     *NodeGrants
+	NodeGrants  []*Grant
 	ParentNode  *Node
 	ParentNodes []*Node
 	User        *User
 
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
 	ServerResponse `json:"-"`
 }
 
 type NodesCreateCall struct {
+	*Call[Node]
 	parentNodeID string
 	node         *Node
-
-	s         *Service
-	urlParams url.Values
 }
 
-func (c *NodesCreateCall) doRequest() (*http.Response, error) {
-	urls := resolveRelative(c.s.BasePath, "node/"+c.parentNodeID) + "!children"
-	urls += "?" + encodeURLParams(c.urlParams)
-	body, err := json.Marshal(c.node)
-	if err != nil {
-		return nil, err
-	}
-	req, _ := http.NewRequest("POST", urls, bytes.NewReader(body))
-	c.s.setHeaders(req)
-	debugRequest(req)
-	return c.s.client.Do(req)
+func (c *NodesCreateCall) Context(ctx context.Context) *NodesCreateCall {
+	c.Call.Context(ctx)
+	return c
 }
 
+// Do validates the node, deriving its UrlName from Name when empty, and
+// creates it. Validation problems are returned as a *ValidationError
+// without a request being sent.
 func (c *NodesCreateCall) Do() (*Node, error) {
 	if c.parentNodeID == "" {
 		return nil, fmt.Errorf("parentNodeID is empty")
 	} else if c.node == nil {
 		return nil, fmt.Errorf("node is nil")
 	}
-	res, err := c.doRequest()
-	if err != nil {
-		return nil, err
-		// } else if res.StatusCode != 201 && res.StatusCode != 409 {
-		// 	return nil, fmt.Errorf("Failed to create Node (status code: %d)", res.StatusCode)
-	}
-	debugResponse(res)
-	defer closeBody(res)
-	if err := checkResponse(res); err != nil {
+	if err := c.node.Validate(); err != nil {
 		return nil, err
 	}
-	nodesRes := &NodesServiceResponse{}
-	if err := json.NewDecoder(res.Body).Decode(&nodesRes); err != nil {
-		return nil, err
-	}
-	node := &Node{}
-	if err := json.Unmarshal(*nodesRes.Response.Node, &node); err != nil {
-		return nil, err
+	node := *c.node
+	if node.URLName == "" {
+		node.URLName = URLName(node.Name)
 	}
-	return node, nil
+	c.body = &node
+	return c.Call.Do()
 }
 
 type Node struct {
 	DateAdded             *time.Time       `json:",omitempty"`
 	DateModified          *time.Time       `json:",omitempty"`
 	Description           string           `json:",omitempty"`
-	EffectivePrivacy      string           `json:",omitempty"`
-	EffectiveSecurityType string           `json:",omitempty"`
+	EffectivePrivacy      Privacy          `json:",omitempty"`
+	EffectiveSecurityType SecurityType     `json:",omitempty"`
 	FormattedValues       *FormattedValues `json:",omitempty"`
 	HasChildren           bool             `json:",omitempty"`
 	HideOwner             bool             `json:",omitempty"`
@@ -197,19 +258,22 @@ type Node struct {
 	NodeID                string           `json:",omitempty"`
 	Password              string           `json:",omitempty"`
 	PasswordHint          string           `json:",omitempty"`
-	Privacy               string           `json:",omitempty"`
-	SecurityType          string           `json:",omitempty"`
-	SmugSearchable        string           `json:",omitempty"`
-	SortDirection         string           `json:",omitempty"`
-	SortIndex             int              `json:",omitempty"`
-	SortMethod            string           `json:",omitempty"`
-	Type                  string           `json:",omitempty"`
+	Privacy               Privacy          `json:",omitempty"`
+	SecurityType          SecurityType     `json:",omitempty"`
+	SmugSearchable        SmugSearchable   `json:",omitempty"`
+	SortDirection         SortDirection    `json:",omitempty"`
+	SortIndex             int              `json:",omitempty"` // 0 is never sent; see NodePatch
+	SortMethod            SortMethod       `json:",omitempty"`
+	Type                  NodeType         `json:",omitempty"`
 	URLName               string           `json:"UrlName,omitempty"`
 	URLPath               string           `json:"UrlPath,omitempty"`
-	WorldSearchable       string           `json:",omitempty"`
+	WorldSearchable       WorldSearchable  `json:",omitempty"`
 
 	ResponseLevel string `json:",omitempty"`
 	URI           string `json:"Uri,omitempty"`
 	URIs          *URIs  `json:"Uris,omitempty"`
 	WebURI        string `json:"WebUri,omitempty"`
+
+	Expansions Expansions                 `json:"-"`
+	Extra      map[string]json.RawMessage `json:"-"`
 }
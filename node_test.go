@@ -0,0 +1,78 @@
+package smugmug
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNodesGet(t *testing.T) {
+	s := newTestService(t)
+	res, err := s.Nodes.Get("zx4Fx").Expand([]string{"ChildNodes", "ParentNodes", "User"}).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := res.Node
+	if n.NodeID != "zx4Fx" || n.Type != "Folder" || n.URLPath != "/Travel" || !n.HasChildren {
+		t.Errorf("Node = %+v", n)
+	}
+	if len(res.ChildNodes) != 2 || res.ChildNodes[0].Name != "Paris" || res.ChildNodes[1].Name != "Iceland" {
+		t.Errorf("ChildNodes = %+v", res.ChildNodes)
+	}
+	if len(res.ParentNodes) != 1 || !res.ParentNodes[0].IsRoot {
+		t.Errorf("ParentNodes = %+v", res.ParentNodes)
+	}
+	if res.User == nil || res.User.NickName != "cmac" {
+		t.Errorf("User = %+v", res.User)
+	}
+	if res.Album != nil || res.ParentNode != nil || res.HighlightImage != nil {
+		t.Error("unexpected expansions")
+	}
+}
+
+func TestNodesGetNested(t *testing.T) {
+	s := newTestService(t)
+	res, err := s.Nodes.Get("zx4Fx").ExpandTree(
+		NewExpansion("ChildNodes").Arg("count", 2).Expand(
+			NewExpansion("Album").Expand(
+				NewExpansion("HighlightImage").Expand(
+					NewExpansion("ImageSizes"))))).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.ChildNodes) != 2 {
+		t.Fatalf("ChildNodes = %+v", res.ChildNodes)
+	}
+	if c, ok := ExpansionOf[[]*Node](res.Node.Expansions, "ChildNodes"); !ok || len(c) != 2 {
+		t.Errorf("Node.Expansions = %+v", res.Node.Expansions)
+	}
+	for i, want := range []string{"SD5BL92", "Vk2mQ9x"} {
+		album, ok := ExpansionOf[*Album](res.ChildNodes[i].Expansions, "Album")
+		if !ok {
+			t.Fatalf("child %d has no Album expansion: %+v", i, res.ChildNodes[i].Expansions)
+		}
+		image, ok := ExpansionOf[*Image](album.Expansions, "HighlightImage")
+		if !ok || image.ImageKey != want {
+			t.Fatalf("child %d HighlightImage = %+v", i, album.Expansions)
+		}
+		sizes, ok := ExpansionOf[*ImageSizes](image.Expansions, "ImageSizes")
+		if !ok || !strings.Contains(sizes.ThumbImageURL, want) {
+			t.Errorf("child %d ImageSizes = %+v", i, image.Expansions)
+		}
+		// ImageAlbum points back at the album being decoded; the cycle
+		// must stop there.
+		if back, ok := ExpansionOf[*Album](image.Expansions, "ImageAlbum"); !ok || back.AlbumKey != album.AlbumKey || back.Expansions != nil {
+			t.Errorf("child %d ImageAlbum = %+v", i, back)
+		}
+	}
+}
+
+func TestExpansionConfig(t *testing.T) {
+	got := configParam([]*Expansion{
+		NewExpansion("ChildNodes").Arg("count", 5).Filter("Name", "Type").Expand(NewExpansion("Album")),
+		NewExpansion("User"),
+	})
+	want := `{"expand":{"ChildNodes":{"args":{"count":5},"expand":{"Album":{}},"filter":["Name","Type"]},"User":{}}}`
+	if got != want {
+		t.Errorf("configParam = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+package smugmug_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestEnsurePath(t *testing.T) {
+	fake, s, root := newFake(t)
+	clients := fake.AddNode(root, &smugmug.Node{Name: "Clients", Type: smugmug.NodeTypeFolder})
+
+	ctx := context.Background()
+	defaults := &smugmug.Node{Privacy: smugmug.PrivacyUnlisted}
+
+	n, err := s.Nodes.EnsurePath(ctx, root, "Clients/2026/Smith Wedding", smugmug.NodeTypeAlbum, defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Type != smugmug.NodeTypeAlbum || n.URLPath != "/Clients/2026/Smith-Wedding" || n.Privacy != smugmug.PrivacyUnlisted {
+		t.Errorf("got %s %s %s", n.Type, n.URLPath, n.Privacy)
+	}
+	year, err := s.Nodes.EnsurePath(ctx, root, "/clients/2026/", smugmug.NodeTypeFolder, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if year.URLPath != "/Clients/2026" || fake.Node(year.NodeID).Privacy == smugmug.PrivacyUnlisted {
+		t.Errorf("folder: got %+v", year)
+	}
+	if got := fake.Node(clients.NodeID); !got.HasChildren {
+		t.Error("Clients was not reused")
+	}
+
+	again, err := s.Nodes.EnsurePath(ctx, root, "Clients/2026/Smith Wedding", smugmug.NodeTypeAlbum, defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.NodeID != n.NodeID {
+		t.Errorf("second call created %s, want %s", again.NodeID, n.NodeID)
+	}
+
+	if _, err := s.Nodes.EnsurePath(ctx, root, "Clients/2026/Smith Wedding/Extras", smugmug.NodeTypeAlbum, nil); err == nil {
+		t.Error("path through an album: err = nil")
+	}
+
+	template := &smugmug.Node{NodeID: n.NodeID, URI: n.URI, SortMethod: smugmug.SortMethodDateTaken}
+	party, err := s.Nodes.EnsurePath(ctx, root, "Events/2026/Party", smugmug.NodeTypeAlbum, template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if party.NodeID == n.NodeID || party.SortMethod != smugmug.SortMethodDateTaken {
+		t.Errorf("album from template: got %s %s", party.NodeID, party.SortMethod)
+	}
+	events, err := s.Nodes.EnsurePath(ctx, root, "Events/2026", smugmug.NodeTypeFolder, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fake.Node(events.NodeID).SortMethod; got == smugmug.SortMethodDateTaken {
+		t.Errorf("folder on the way sorts by %s", got)
+	}
+
+	var wg sync.WaitGroup
+	ids := make([]string, 4)
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			n, err := s.Nodes.EnsurePath(ctx, root, "Clients/2027/Jones", smugmug.NodeTypeAlbum, nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			ids[i] = n.NodeID
+		}(i)
+	}
+	wg.Wait()
+	for _, id := range ids[1:] {
+		if id != ids[0] {
+			t.Errorf("concurrent calls returned %v", ids)
+			break
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package smugmug_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestReorder(t *testing.T) {
+	fake, s, root := newFake(t)
+	years := fake.AddNode(root, &smugmug.Node{Name: "Years", Type: smugmug.NodeTypeFolder})
+	day := func(d int) *time.Time {
+		t := time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	modified := []*time.Time{day(6), day(5), nil, day(7)}
+	var ids []string
+	for i, name := range []string{"2024", "Week 10", "2023", "Week 9"} {
+		n := fake.AddNode(years.NodeID, &smugmug.Node{Name: name, Type: smugmug.NodeTypeAlbum, DateAdded: day(4 - i), DateModified: modified[i]})
+		ids = append(ids, n.NodeID)
+	}
+
+	ctx := context.Background()
+	patches := func(f func() error) int {
+		t.Helper()
+		before := fake.Requests("PATCH")
+		if err := f(); err != nil {
+			t.Fatal(err)
+		}
+		return fake.Requests("PATCH") - before
+	}
+
+	// Every SortIndex is 0 to begin with, so the first child keeps its own;
+	// the other three and the folder's sort method change.
+	if n := patches(func() error { return s.Nodes.Reorder(ctx, years.NodeID, []string{ids[3], ids[1]}) }); n != 4 {
+		t.Errorf("Reorder sent %d patches, want 4", n)
+	}
+	folder := fake.Node(years.NodeID)
+	if folder.SortMethod != smugmug.SortMethodSortIndex || folder.SortDirection != smugmug.SortDirectionAscending {
+		t.Errorf("folder sorts by %s %s", folder.SortMethod, folder.SortDirection)
+	}
+	want := "Week 9, Week 10, 2024, 2023"
+	if got := childNames(t, s, years.NodeID); got != want {
+		t.Errorf("after Reorder: %q, want %q", got, want)
+	}
+
+	if n := patches(func() error { return s.Nodes.Reorder(ctx, years.NodeID, []string{ids[3], ids[1]}) }); n != 0 {
+		t.Errorf("same order again sent %d patches", n)
+	}
+
+	if err := s.Nodes.SortChildren(ctx, years.NodeID, smugmug.NodesByName); err != nil {
+		t.Fatal(err)
+	}
+	want = "2023, 2024, Week 9, Week 10"
+	if got := childNames(t, s, years.NodeID); got != want {
+		t.Errorf("by name: %q, want %q", got, want)
+	}
+
+	if err := s.Nodes.SortChildren(ctx, years.NodeID, smugmug.NodesByDateAdded); err != nil {
+		t.Fatal(err)
+	}
+	want = "Week 9, 2023, Week 10, 2024"
+	if got := childNames(t, s, years.NodeID); got != want {
+		t.Errorf("by date added: %q, want %q", got, want)
+	}
+
+	// Swapping the first two leaves the last two where they are.
+	if n := patches(func() error { return s.Nodes.Reorder(ctx, years.NodeID, []string{ids[2], ids[3]}) }); n != 2 {
+		t.Errorf("swap sent %d patches, want 2", n)
+	}
+
+	if err := s.Nodes.SortChildren(ctx, years.NodeID, smugmug.NodesByDateModified); err != nil {
+		t.Fatal(err)
+	}
+	want = "Week 10, 2024, Week 9, 2023"
+	if got := childNames(t, s, years.NodeID); got != want {
+		t.Errorf("by date modified: %q, want %q", got, want)
+	}
+
+	if err := s.Nodes.Reorder(ctx, years.NodeID, []string{ids[0], ids[0]}); err == nil {
+		t.Error("Reorder accepted a duplicate ID")
+	}
+	if err := s.Nodes.Reorder(ctx, ids[0], nil); err == nil {
+		t.Error("Reorder accepted an album")
+	}
+}
@@ -0,0 +1,302 @@
+// Package replay records HTTP interactions into golden files and plays them
+// back deterministically.
+//
+// In ModeRecord requests go to the wrapped Transport and every interaction is
+// written to the golden file on Close, with credentials and personal fields
+// scrubbed. In ModeReplay the golden file is loaded and requests are answered
+// from it without touching the network.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type Mode int
+
+const (
+	ModeReplay Mode = iota
+	ModeRecord
+)
+
+const redacted = "REDACTED"
+
+// DefaultScrubHeaders are removed from recorded requests and responses.
+var DefaultScrubHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Smug-Uploadkey",
+}
+
+// DefaultScrubParams are removed from recorded URLs; prefixes end in "*".
+var DefaultScrubParams = []string{
+	"APIKey",
+	"oauth_*",
+}
+
+// DefaultScrubFields are JSON object keys whose values are replaced in
+// recorded bodies.
+var DefaultScrubFields = []string{
+	"CreatorContactInfo",
+	"Email",
+	"FirstName",
+	"LastName",
+	"LensSerialNumber",
+	"Password",
+	"PasswordHint",
+	"RefTag",
+	"SerialNumber",
+	"ViewPassHint",
+	"ViewPassword",
+}
+
+type Request struct {
+	Method string
+	URL    string
+	Header http.Header `json:",omitempty"`
+	Body   string      `json:",omitempty"`
+}
+
+type Response struct {
+	StatusCode int
+	Header     http.Header     `json:",omitempty"`
+	Body       json.RawMessage `json:",omitempty"`
+	RawBody    string          `json:",omitempty"`
+}
+
+type Interaction struct {
+	Request  Request
+	Response Response
+}
+
+type Transport struct {
+	Mode      Mode
+	Transport http.RoundTripper // used in ModeRecord; http.DefaultTransport if nil
+
+	ScrubHeaders []string
+	ScrubParams  []string
+	ScrubFields  []string
+
+	path         string
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+}
+
+// New returns a Transport backed by the golden file at path. In ModeReplay
+// the file must exist.
+func New(path string, mode Mode) (*Transport, error) {
+	t := &Transport{
+		Mode:         mode,
+		ScrubHeaders: DefaultScrubHeaders,
+		ScrubParams:  DefaultScrubParams,
+		ScrubFields:  DefaultScrubFields,
+		path:         path,
+	}
+	if mode == ModeRecord {
+		return t, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &t.interactions); err != nil {
+		return nil, fmt.Errorf("replay: %s: %v", path, err)
+	}
+	t.used = make([]bool, len(t.interactions))
+	return t, nil
+}
+
+// Client returns an http.Client using t.
+func (t *Transport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Mode == ModeRecord {
+		return t.record(req)
+	}
+	return t.replay(req)
+}
+
+// Close writes the recorded interactions in ModeRecord.
+func (t *Transport) Close() error {
+	if t.Mode != ModeRecord {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	data, err := json.MarshalIndent(t.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(t.path, append(data, '\n'), 0644)
+}
+
+func (t *Transport) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	rt := t.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	res, err := rt.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	in := &Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    t.scrubURL(req.URL),
+			Header: t.scrubHeader(req.Header),
+			Body:   string(t.scrubBody(reqBody)),
+		},
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     t.scrubHeader(res.Header),
+		},
+	}
+	if body := t.scrubBody(resBody); json.Valid(body) {
+		in.Response.Body = body
+	} else {
+		in.Response.RawBody = string(body)
+	}
+	t.mu.Lock()
+	t.interactions = append(t.interactions, in)
+	t.mu.Unlock()
+	return res, nil
+}
+
+func (t *Transport) replay(req *http.Request) (*http.Response, error) {
+	u := t.scrubURL(req.URL)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, in := range t.interactions {
+		if t.used[i] || in.Request.Method != req.Method || in.Request.URL != u {
+			continue
+		}
+		t.used[i] = true
+		var buf bytes.Buffer
+		if in.Response.RawBody != "" {
+			buf.WriteString(in.Response.RawBody)
+		} else if err := json.Compact(&buf, in.Response.Body); err != nil {
+			return nil, err
+		}
+		body := buf.Bytes()
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("replay: no recorded response for %s %s in %s", req.Method, u, t.path)
+}
+
+// scrubURL drops credential parameters and normalizes the query order so
+// that recorded and live requests compare equal.
+func (t *Transport) scrubURL(u *url.URL) string {
+	cp := *u
+	q := cp.Query()
+	for k := range q {
+		if matchAny(t.ScrubParams, k) {
+			q.Del(k)
+		}
+	}
+	cp.RawQuery = q.Encode()
+	return cp.String()
+}
+
+func (t *Transport) scrubHeader(h http.Header) http.Header {
+	ret := http.Header{}
+	for k, v := range h {
+		if !matchAny(t.ScrubHeaders, k) {
+			ret[k] = v
+		}
+	}
+	if len(ret) == 0 {
+		return nil
+	}
+	return ret
+}
+
+func (t *Transport) scrubBody(body []byte) []byte {
+	var v interface{}
+	if len(body) == 0 || json.Unmarshal(body, &v) != nil {
+		return body
+	}
+	v = t.scrubValue(v)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return body
+	}
+	return bytes.TrimSpace(buf.Bytes())
+}
+
+func (t *Transport) scrubValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if _, ok := e.(string); ok && matchAny(t.ScrubFields, k) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = t.scrubValue(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = t.scrubValue(e)
+		}
+	}
+	return v
+}
+
+func matchAny(patterns []string, s string) bool {
+	for _, p := range patterns {
+		if strings.HasSuffix(p, "*") {
+			if strings.HasPrefix(strings.ToLower(s), strings.ToLower(strings.TrimSuffix(p, "*"))) {
+				return true
+			}
+		} else if strings.EqualFold(p, s) {
+			return true
+		}
+	}
+	return false
+}
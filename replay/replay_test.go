@@ -0,0 +1,65 @@
+package replay
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		io.WriteString(w, `{"User":{"NickName":"cmac","FirstName":"Chris","ImageCount":7}}`)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "golden.json")
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest("GET", srv.URL+"/api/v2/user/cmac?b=2&oauth_token=tok&a=1", nil)
+	req.Header.Set("Authorization", "OAuth oauth_signature=secret")
+	res, err := rec.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	live, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	if !strings.Contains(string(live), "Chris") {
+		t.Errorf("record mode altered the live response: %s", live)
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+
+	rep, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := rep.interactions[0]
+	if in.Request.Header.Get("Authorization") != "" || in.Response.Header.Get("Set-Cookie") != "" {
+		t.Errorf("credentials were recorded: %+v", in)
+	}
+	if strings.Contains(in.Request.URL, "oauth_token") {
+		t.Errorf("credential parameter was recorded: %s", in.Request.URL)
+	}
+
+	res, err = rep.Client().Get(srv.URL + "/api/v2/user/cmac?a=1&b=2&oauth_token=other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	if strings.Contains(string(body), "Chris") || !strings.Contains(string(body), `"FirstName":"REDACTED"`) {
+		t.Errorf("personal field was not scrubbed: %s", body)
+	}
+	if _, err := rep.Client().Get(srv.URL + "/api/v2/user/cmac?a=1&b=2"); err == nil {
+		t.Error("expected an error once the recorded interaction was used")
+	}
+}
@@ -0,0 +1,95 @@
+package smugmug
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// AlbumShareURIs holds the addresses an album can be shared by. UnlistedURL
+// carries the key that opens an unlisted album; ShortURL redirects to
+// whichever of the two applies.
+type AlbumShareURIs struct {
+	PublicURL   string `json:"PublicUrl,omitempty"`
+	ShortURL    string `json:"ShortUrl,omitempty"`
+	UnlistedURL string `json:"UnlistedUrl,omitempty"`
+
+	URI            string `json:"Uri,omitempty"`
+	URIDescription string `json:"UriDescription,omitempty"`
+}
+
+// ShareLink is a link to send a guest together with what they need to open
+// it.
+type ShareLink struct {
+	URL          string
+	Password     string // set when the album is password protected
+	PasswordHint string
+	GrantOnly    bool // only people given a grant (see NodesService.AddGrant) can open it
+}
+
+// ImageShareLink composes the link to image imageKey in album, or to the
+// album itself when imageKey is empty, from the album's share URIs:
+//
+//   - public albums use PublicURL, unlisted ones UnlistedURL, which is
+//     required;
+//   - private albums can only be shared with SecurityType GrantAccess, and
+//     the link is marked GrantOnly;
+//   - password protected albums need album.Password, which SmugMug only
+//     returns to the owner, and the link carries it.
+func ImageShareLink(album *Album, shares *AlbumShareURIs, imageKey string) (*ShareLink, error) {
+	if album == nil || shares == nil {
+		return nil, fmt.Errorf("album and shares are required")
+	}
+	link := &ShareLink{}
+	base := shares.PublicURL
+	switch album.Privacy {
+	case PrivacyUnlisted:
+		base = shares.UnlistedURL
+	case PrivacyPrivate:
+		if album.SecurityType != SecurityTypeGrantAccess {
+			return nil, fmt.Errorf("album %s is private; set SecurityType GrantAccess to share it", album.AlbumKey)
+		}
+		link.GrantOnly = true
+	}
+	if base == "" && album.Privacy != PrivacyUnlisted {
+		// WebUri lacks the key an unlisted album needs, so only public
+		// and grant-only albums fall back to it.
+		base = album.WebURI
+	}
+	if base == "" {
+		return nil, fmt.Errorf("album %s has no %s share URL", album.AlbumKey, album.Privacy)
+	}
+	if album.SecurityType == SecurityTypePassword {
+		if album.Password == "" {
+			return nil, fmt.Errorf("album %s is password protected but its Password was not returned", album.AlbumKey)
+		}
+		link.Password, link.PasswordHint = album.Password, album.PasswordHint
+	}
+	if imageKey == "" {
+		link.URL = base
+		return link, nil
+	}
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/i-" + imageKey
+	link.URL = u.String()
+	return link, nil
+}
+
+// ShareLink fetches the album with its share URIs expanded and composes a
+// link to image imageKey with ImageShareLink. SmugMug only returns the
+// Password of a password protected album to its owner, so only they can
+// share one.
+func (r *AlbumsService) ShareLink(ctx context.Context, albumKey, imageKey string) (*ShareLink, error) {
+	res, err := r.GetAlbum(ctx, albumKey, Expand("AlbumShareUris"))
+	if err != nil {
+		return nil, err
+	}
+	if res.AlbumShareUris == nil {
+		return nil, fmt.Errorf("album %s has no AlbumShareUris", albumKey)
+	}
+	return ImageShareLink(res.Album, res.AlbumShareUris, imageKey)
+}
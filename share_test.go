@@ -0,0 +1,75 @@
+package smugmug_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestImageShareLink(t *testing.T) {
+	shares := &smugmug.AlbumShareURIs{
+		PublicURL:   "https://cmac.smugmug.com/Clients/Smith",
+		UnlistedURL: "https://cmac.smugmug.com/Clients/Smith/n-Xw7pQ/",
+	}
+	for _, tt := range []struct {
+		name  string
+		album smugmug.Album
+		want  smugmug.ShareLink
+		fails bool
+	}{
+		{name: "public", album: smugmug.Album{Privacy: smugmug.PrivacyPublic},
+			want: smugmug.ShareLink{URL: "https://cmac.smugmug.com/Clients/Smith/i-abc"}},
+		{name: "unlisted", album: smugmug.Album{Privacy: smugmug.PrivacyUnlisted},
+			want: smugmug.ShareLink{URL: "https://cmac.smugmug.com/Clients/Smith/n-Xw7pQ/i-abc"}},
+		{name: "password", album: smugmug.Album{Privacy: smugmug.PrivacyUnlisted, SecurityType: smugmug.SecurityTypePassword, Password: "cake", PasswordHint: "dessert"},
+			want: smugmug.ShareLink{URL: "https://cmac.smugmug.com/Clients/Smith/n-Xw7pQ/i-abc", Password: "cake", PasswordHint: "dessert"}},
+		{name: "password not returned", album: smugmug.Album{SecurityType: smugmug.SecurityTypePassword}, fails: true},
+		{name: "private", album: smugmug.Album{Privacy: smugmug.PrivacyPrivate}, fails: true},
+		{name: "grant access", album: smugmug.Album{Privacy: smugmug.PrivacyPrivate, SecurityType: smugmug.SecurityTypeGrantAccess},
+			want: smugmug.ShareLink{URL: "https://cmac.smugmug.com/Clients/Smith/i-abc", GrantOnly: true}},
+	} {
+		got, err := smugmug.ImageShareLink(&tt.album, shares, "abc")
+		if tt.fails {
+			if err == nil {
+				t.Errorf("%s: err = nil, link %+v", tt.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		} else if *got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, *got, tt.want)
+		}
+	}
+
+	unlisted := &smugmug.Album{Privacy: smugmug.PrivacyUnlisted, WebURI: "https://cmac.smugmug.com/Clients/Smith"}
+	if got, err := smugmug.ImageShareLink(unlisted, &smugmug.AlbumShareURIs{PublicURL: shares.PublicURL}, "abc"); err == nil {
+		t.Errorf("unlisted without UnlistedUrl: err = nil, link %+v", got)
+	}
+}
+
+func TestAlbumShareLink(t *testing.T) {
+	fake, s, root := newFake(t)
+	a := fake.AddAlbum(root, &smugmug.Album{Name: "Smith Wedding", Privacy: smugmug.PrivacyUnlisted})
+	img := fake.AddImage(a.AlbumKey, &smugmug.Image{FileName: "vows.jpg"}, []byte("vows"))
+
+	ctx := context.Background()
+
+	shares, err := s.Albums.ShareURIs(a.AlbumKey).Context(ctx).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := shares.AlbumShareUris
+	if got.PublicURL != fake.URL+"/Smith-Wedding" || got.UnlistedURL == "" || got.ShortURL == "" {
+		t.Errorf("AlbumShareUris = %+v", got)
+	}
+
+	link, err := s.Albums.ShareLink(ctx, a.AlbumKey, img.ImageKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := got.UnlistedURL + "/i-" + img.ImageKey; link.URL != want {
+		t.Errorf("ShareLink = %q, want %q", link.URL, want)
+	}
+}
@@ -1,7 +1,6 @@
 package smugmug
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,19 +8,21 @@ import (
 	"strings"
 )
 
+//go:generate go run ./internal/endpointgen -o endpoints_gen.go endpoints
+
 const basePath = "https://api.smugmug.com/api/v2/"
 
 type FormattedValues struct {
 	Caption struct {
-		HTML string `json:html`
-		Text string `json:text`
+		HTML string `json:"html"`
+		Text string `json:"text"`
 	}
 	Name struct {
-		HTML string `json:html`
+		HTML string `json:"html"`
 	}
 	Description struct {
-		HTML string `json:html`
-		Text string `json:text`
+		HTML string `json:"html"`
+		Text string `json:"text"`
 	}
 }
 
@@ -44,6 +45,7 @@ type Service struct {
 	client    *http.Client
 	BasePath  string // API endpoint base URL
 	UserAgent string // optional additional User-Agent fragment
+	Strict    bool   // fail responses containing fields the models do not define
 
 	Albums *AlbumsService
 	Images *ImagesService
@@ -78,16 +80,55 @@ type ServiceResponse struct {
 	}
 }
 
+// Pages describes the position of a page of results in a collection.
+type Pages struct {
+	Total          int
+	Start          int
+	Count          int
+	RequestedCount int
+	FirstPage      string `json:",omitempty"`
+	LastPage       string `json:",omitempty"`
+	NextPage       string `json:",omitempty"`
+	PrevPage       string `json:",omitempty"`
+}
+
 type ServerResponse struct {
 	HTTPStatusCode int
 	Header         http.Header
 }
 
-func New(client *http.Client) (*Service, error) {
+// String, Bool, Int and Float64 return a pointer to their argument, for the
+// optional fields of patch types such as ImagePatch.
+func String(v string) *string    { return &v }
+func Bool(v bool) *bool          { return &v }
+func Int(v int) *int             { return &v }
+func Float64(v float64) *float64 { return &v }
+
+type Option func(*Service)
+
+// WithBaseURL points the service at a different API host, e.g. a test server.
+func WithBaseURL(baseURL string) Option {
+	return func(s *Service) {
+		s.BasePath = resolveRelative(baseURL, "/api/v2/")
+	}
+}
+
+// WithStrict makes calls return an *UnknownFieldsError when a response
+// carries fields that would otherwise only land in a model's Extra.
+func WithStrict() Option {
+	return func(s *Service) {
+		s.Strict = true
+	}
+}
+
+func New(client *http.Client, opts ...Option) (*Service, error) {
 	if client == nil {
 		return nil, fmt.Errorf("client is nil")
 	}
 	s := &Service{client: client, BasePath: basePath}
+	for _, opt := range opts {
+		opt(s)
+	}
 	s.Albums = NewAlbumsService(s)
 	s.Images = NewImagesService(s)
 	s.Nodes = NewNodesService(s)
@@ -99,9 +140,19 @@ func closeBody(res *http.Response) error {
 	return res.Body.Close()
 }
 
+// APIError is returned for responses with an error status.
+type APIError struct {
+	Code   int    // HTTP status code, e.g. 409
+	Status string // e.g. "409 Conflict"
+}
+
+func (e *APIError) Error() string {
+	return e.Status
+}
+
 func checkResponse(res *http.Response) error {
 	if res.StatusCode >= 400 {
-		return fmt.Errorf("%s", res.Status)
+		return &APIError{Code: res.StatusCode, Status: res.Status}
 	}
 	return nil
 }
@@ -131,101 +182,3 @@ func resolveRelative(basestr string, relstr string) string {
 	us = strings.Replace(us, "%7D", "}", -1)
 	return us
 }
-
-func unmarshallExpansions(uris *URIs, exp map[string]*json.RawMessage) (map[string]interface{}, error) {
-	ret := map[string]interface{}{}
-	for name, uri := range *uris {
-		u := parseURI(uri)
-		switch name {
-		case "Album", "ImageAlbum":
-			if value, ok := exp[u]; ok {
-				res := struct{ Album *Album }{}
-				if err := json.Unmarshal(*value, &res); err != nil {
-					return nil, err
-				}
-				ret[name] = res.Album
-			}
-		case "Node", "ParentNode":
-			if value, ok := exp[u]; ok {
-				res := struct{ Node *Node }{}
-				if err := json.Unmarshal(*value, &res); err != nil {
-					return nil, err
-				}
-				ret[name] = res.Node
-			}
-		case "ChildNodes", "ParentNodes":
-			if value, ok := exp[u]; ok {
-				res := struct{ Node []*Node }{}
-				if err := json.Unmarshal(*value, &res); err != nil {
-					return nil, err
-				}
-				ret[name] = res.Node
-			}
-		case "HighlightImage":
-			if value, ok := exp[u]; ok {
-				res := struct{ Image *Image }{}
-				if err := json.Unmarshal(*value, &res); err != nil {
-					return nil, err
-				}
-				ret[name] = res.Image
-			}
-		case "ImageDownload":
-			if value, ok := exp[u]; ok {
-				res := struct{ ImageDownload *ImageDownload }{}
-				if err := json.Unmarshal(*value, &res); err != nil {
-					return nil, err
-				}
-				ret[name] = res.ImageDownload
-			}
-		case "ImageMetadata":
-			if value, ok := exp[u]; ok {
-				res := struct{ ImageMetadata *ImageMetadata }{}
-				if err := json.Unmarshal(*value, &res); err != nil {
-					return nil, err
-				}
-				ret[name] = res.ImageMetadata
-			}
-		case "User", "ImageOwner":
-			if value, ok := exp[u]; ok {
-				res := struct{ User *User }{}
-				if err := json.Unmarshal(*value, &res); err != nil {
-					return nil, err
-				}
-				ret[name] = res.User
-			}
-		case "ImagePrices":
-			if value, ok := exp[u]; ok {
-				res := struct{ CatalogSkuPrice []*CatalogSkuPrice }{}
-				if err := json.Unmarshal(*value, &res); err != nil {
-					return nil, err
-				}
-				ret[name] = res.CatalogSkuPrice
-			}
-		case "ImageSizeDetails":
-			if value, ok := exp[u]; ok {
-				res := struct{ ImageSizeDetails *ImageSizeDetails }{}
-				if err := json.Unmarshal(*value, &res); err != nil {
-					return nil, err
-				}
-				ret[name] = res.ImageSizeDetails
-			}
-		case "ImageSizes":
-			if value, ok := exp[u]; ok {
-				res := struct{ ImageSizes *ImageSizes }{}
-				if err := json.Unmarshal(*value, &res); err != nil {
-					return nil, err
-				}
-				ret[name] = res.ImageSizes
-			}
-		case "LargestImage":
-			if value, ok := exp[u]; ok {
-				res := struct{ LargestImage *LargestImage }{}
-				if err := json.Unmarshal(*value, &res); err != nil {
-					return nil, err
-				}
-				ret[name] = res.LargestImage
-			}
-		}
-	}
-	return ret, nil
-}
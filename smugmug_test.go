@@ -0,0 +1,251 @@
+package smugmug
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pilwon/go-smugmug/replay"
+)
+
+var record = flag.Bool("record", false, "record golden files in testdata against the live API using $SMUGMUG_API_KEY")
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	debug = false
+	os.Exit(m.Run())
+}
+
+type apiKeyTransport struct {
+	key string
+}
+
+func (t apiKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	q := req.URL.Query()
+	q.Set("APIKey", t.key)
+	req.URL.RawQuery = q.Encode()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	mode := replay.ModeReplay
+	if *record {
+		mode = replay.ModeRecord
+	}
+	tr, err := replay.New(filepath.Join("testdata", t.Name()+".json"), mode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *record {
+		tr.Transport = apiKeyTransport{key: os.Getenv("SMUGMUG_API_KEY")}
+	}
+	t.Cleanup(func() {
+		if err := tr.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	s, err := New(tr.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestNewNilClient(t *testing.T) {
+	if _, err := New(nil); err == nil {
+		t.Fatal("expected error for nil client")
+	}
+}
+
+func TestUnmarshallExpansions(t *testing.T) {
+	uris := &URIs{
+		"Node":        "/api/v2/node/9hR7c",
+		"ChildNodes":  map[string]interface{}{"Uri": "/api/v2/node/9hR7c!children", "Locator": "Node"},
+		"User":        "/api/v2/user/cmac",
+		"ImagePrices": "/api/v2/image/SD5BL92-1!prices",
+		"Unexpanded":  "/api/v2/album/kQ3t8P!images",
+	}
+	exp := map[string]*json.RawMessage{
+		"/api/v2/node/9hR7c":             rawMessage(`{"Node":{"NodeID":"9hR7c","Type":"Album"}}`),
+		"/api/v2/node/9hR7c!children":    rawMessage(`{"Node":[{"NodeID":"a"},{"NodeID":"b"}]}`),
+		"/api/v2/user/cmac":              rawMessage(`{"User":{"NickName":"cmac"}}`),
+		"/api/v2/image/SD5BL92-1!prices": rawMessage(`{"CatalogSkuPrice":[{"Currency":"USD","Price":1.99}]}`),
+	}
+	ret, err := unmarshallExpansions(uris, exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ret) != 4 {
+		t.Errorf("got %d expansions, want 4", len(ret))
+	}
+	if n := ret["Node"].(*Node); n.NodeID != "9hR7c" || n.Type != "Album" {
+		t.Errorf("Node = %+v", n)
+	}
+	if c := ret["ChildNodes"].([]*Node); len(c) != 2 || c[1].NodeID != "b" {
+		t.Errorf("ChildNodes = %+v", c)
+	}
+	if u := ret["User"].(*User); u.NickName != "cmac" {
+		t.Errorf("User = %+v", u)
+	}
+	if p := ret["ImagePrices"].([]*CatalogSkuPrice); len(p) != 1 || p[0].Price != 1.99 {
+		t.Errorf("ImagePrices = %+v", p)
+	}
+	if _, ok := ret["Unexpanded"]; ok {
+		t.Error("unexpected expansion for a link that was not expanded")
+	}
+}
+
+func TestUnmarshallExpansionsMalformed(t *testing.T) {
+	uris := &URIs{"Node": "/api/v2/node/9hR7c"}
+	exp := map[string]*json.RawMessage{
+		"/api/v2/node/9hR7c": rawMessage(`{"Node":[1,2,3]}`),
+	}
+	if _, err := unmarshallExpansions(uris, exp); err == nil {
+		t.Fatal("expected decode error")
+	}
+}
+
+func rawMessage(s string) *json.RawMessage {
+	m := json.RawMessage(s)
+	return &m
+}
+
+func TestDecodeExpansions(t *testing.T) {
+	uris := &URIs{
+		"Node":          "/api/v2/node/9hR7c",
+		"User":          "/api/v2/user/cmac",
+		"ImageComments": "/api/v2/image/SD5BL92-1!comments",
+	}
+	exp := map[string]*json.RawMessage{
+		"/api/v2/node/9hR7c":               rawMessage(`{"Node":{"NodeID":"9hR7c"}}`),
+		"/api/v2/user/cmac":                rawMessage(`{"User":{"NickName":"cmac"}}`),
+		"/api/v2/image/SD5BL92-1!comments": rawMessage(`{"Comment":[{"Text":"Lovely"}]}`),
+	}
+	ret := &AlbumsGetResponse{}
+	other, err := decodeExpansions(ret, &Album{URIs: uris}, exp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret.Node == nil || ret.Node.NodeID != "9hR7c" || ret.User == nil || ret.User.NickName != "cmac" {
+		t.Errorf("response = %+v", ret)
+	}
+	if len(other) != 1 || string(other["ImageComments"]) != `{"Comment":[{"Text":"Lovely"}]}` {
+		t.Errorf("other = %s", other)
+	}
+
+	ret = &AlbumsGetResponse{}
+	if other, err = decodeExpansions(ret, &Album{}, exp); err != nil || other != nil {
+		t.Errorf("nil Uris: other = %v, err = %v", other, err)
+	}
+}
+
+func TestChunkKeys(t *testing.T) {
+	var keys []string
+	for i := 0; i < 500; i++ {
+		keys = append(keys, fmt.Sprintf("K%06d-0", i))
+	}
+	base := basePath + "image/"
+	chunks := chunkKeys(base, "image", keys)
+	if len(chunks) < 2 {
+		t.Fatalf("got %d chunks", len(chunks))
+	}
+	n := 0
+	for _, chunk := range chunks {
+		uris := make([]string, len(chunk))
+		for i, key := range chunk {
+			uris[i] = multiURI(base, "image", key)
+		}
+		u := base + "?" + encodeURLParams(url.Values{"_multiuri": {strings.Join(uris, ",")}})
+		if len(u) > maxURLLength {
+			t.Errorf("URL length %d exceeds %d", len(u), maxURLLength)
+		}
+		n += len(chunk)
+	}
+	if n != len(keys) {
+		t.Errorf("chunks hold %d keys, want %d", n, len(keys))
+	}
+}
+
+func TestGetManySharedOptions(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		for _, uri := range strings.Split(r.URL.Query().Get("_multiuri"), ",") {
+			seen[uri]++
+		}
+		mu.Unlock()
+		http.Error(w, "nope", http.StatusNotFound)
+	}))
+	defer ts.Close()
+	s, err := New(ts.Client(), WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for i := 0; i < 500; i++ {
+		keys = append(keys, fmt.Sprintf("K%06d", i))
+	}
+	// Spare capacity invites the chunks to append into one backing array.
+	opts := make([]CallOption, 0, 8)
+	getMany(context.Background(), s, "image", keys, func(i *Image) string { return i.ImageKey }, opts...)
+	if len(seen) != len(keys) {
+		t.Errorf("requested %d distinct URIs, want %d", len(seen), len(keys))
+	}
+	for uri, n := range seen {
+		if n != 1 {
+			t.Errorf("%s requested %d times", uri, n)
+		}
+	}
+}
+
+func TestPagesStopsOnEmptyPage(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 3 {
+			http.Error(w, "looping", http.StatusTeapot)
+			return
+		}
+		fmt.Fprint(w, `{"Code":200,"Response":{"Locator":"Node","LocatorType":"Objects",
+			"Pages":{"Total":5,"Start":1,"Count":0,"RequestedCount":100,"NextPage":"/api/v2/node/n1!children?start=1"}}}`)
+	}))
+	defer ts.Close()
+	s, err := New(ts.Client(), WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Nodes.Children("n1").Pages(context.Background(), func(*NodesChildrenResponse) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("made %d requests, want 1", requests)
+	}
+}
+
+func TestMissingLocator(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Code":200,"Response":{"Node":{"NodeID":"n1"}}}`)
+	}))
+	defer ts.Close()
+	s, err := New(ts.Client(), WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Users.LookupPath("cmac", "/Travel").Do()
+	if err == nil || !strings.Contains(err.Error(), "no Locator") {
+		t.Errorf("err = %v", err)
+	}
+}
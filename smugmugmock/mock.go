@@ -0,0 +1,39 @@
+// Package smugmugmock provides mock implementations of the smugmug service
+// interfaces for unit tests.
+//
+//	albums := &smugmugmock.AlbumsAPI{
+//		GetAlbumFunc: func(ctx context.Context, key string, opts ...smugmug.CallOption) (*smugmug.AlbumsGetResponse, error) {
+//			return &smugmug.AlbumsGetResponse{Album: &smugmug.Album{AlbumKey: key}}, nil
+//		},
+//	}
+//	code.Under(test).Using(albums)
+//	albums.Calls() // [{GetAlbum [ctx key opts]}]
+package smugmugmock
+
+import (
+	"sync"
+)
+
+// Call is a method invocation recorded by a mock.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the method invocations in the order they happened.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
@@ -0,0 +1,33 @@
+package smugmugmock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestNodesAPI(t *testing.T) {
+	errConflict := errors.New("409 Conflict")
+	m := &NodesAPI{
+		CreateNodeFunc: func(ctx context.Context, parentNodeID string, node *smugmug.Node, opts ...smugmug.CallOption) (*smugmug.Node, error) {
+			return nil, errConflict
+		},
+	}
+	var api smugmug.NodesAPI = m
+	if _, err := api.CreateNode(context.Background(), "root", &smugmug.Node{Name: "Clients"}); err != errConflict {
+		t.Errorf("CreateNode error = %v", err)
+	}
+	calls := m.Calls()
+	if len(calls) != 1 || calls[0].Method != "CreateNode" || calls[0].Args[1] != "root" {
+		t.Errorf("Calls = %+v", calls)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for unset GetNodeFunc")
+		}
+	}()
+	api.GetNode(context.Background(), "root")
+}
@@ -0,0 +1,690 @@
+package smugmugtest
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+type user struct {
+	*smugmug.User
+	rootNodeID string
+}
+
+type node struct {
+	*smugmug.Node
+	owner    string
+	parentID string
+	children []string
+	albumKey string
+}
+
+type album struct {
+	*smugmug.Album
+	owner            string
+	nodeID           string
+	images           []string
+	featured         bool
+	downloadPassword string
+	download         *zipDownload
+}
+
+// zipDownload is a requested zip archive of an album. Its parts turn Ready
+// on the second status check that lists them.
+type zipDownload struct {
+	parts  []*smugmug.AlbumDownload
+	data   [][]byte
+	checks int
+}
+
+type image struct {
+	*smugmug.Image
+	owner    string
+	albumKey string
+	metadata *smugmug.ImageMetadata
+	comments []string
+	views    int
+	data     []byte
+}
+
+type comment struct {
+	*smugmug.Comment
+	imageKey string
+}
+
+type grant struct {
+	*smugmug.Grant
+	owner  string
+	nodeID string
+}
+
+type link struct {
+	name        string
+	uri         string
+	locator     string
+	locatorType string
+}
+
+// AddUser stores u along with an empty root folder and returns the stored
+// copy. The first user added becomes the authenticated user.
+func (s *Server) AddUser(u *smugmug.User) *smugmug.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u.NickName == "" {
+		panic("smugmugtest: user NickName is required")
+	}
+	cp := *u
+	usr := &user{User: &cp}
+	s.users[cp.NickName] = usr
+	root := s.addNode(nil, &smugmug.Node{Name: cp.Name, Type: "Folder", IsRoot: true})
+	root.owner = cp.NickName
+	usr.rootNodeID = root.NodeID
+	if s.authUser == "" {
+		s.authUser = cp.NickName
+	}
+	return usr.User
+}
+
+// SetAuthUser selects the user returned by !authuser.
+func (s *Server) SetAuthUser(nickname string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authUser = nickname
+}
+
+// RootNode returns the root folder of the named user.
+func (s *Server) RootNode(nickname string) *smugmug.Node {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[nickname]
+	if !ok {
+		return nil
+	}
+	return s.nodes[u.rootNodeID].Node
+}
+
+// AddNode stores n as a child of the folder parentID and returns the stored
+// copy. Nodes of Type "Album" get a backing album.
+func (s *Server) AddNode(parentID string, n *smugmug.Node) *smugmug.Node {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	parent := s.mustNode(parentID)
+	cp := *n
+	return s.addNode(parent, &cp).Node
+}
+
+// AddAlbum creates an album node under the folder parentID and returns the
+// stored album.
+func (s *Server) AddAlbum(parentID string, a *smugmug.Album) *smugmug.Album {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	parent := s.mustNode(parentID)
+	cp := *a
+	n := s.addNode(parent, &smugmug.Node{
+		Name:    cp.Name,
+		Type:    "Album",
+		URLName: cp.URLName,
+		Privacy: cp.Privacy,
+	})
+	alb := s.albums[n.albumKey]
+	key := alb.AlbumKey
+	*alb.Album = cp
+	alb.AlbumKey, alb.NodeID = key, n.NodeID
+	alb.Name, alb.Privacy = n.Name, n.Privacy
+	alb.URLName, alb.URLPath = n.URLName, n.URLPath
+	return alb.Album
+}
+
+// AddImage stores img with the given file contents in the album albumKey and
+// returns the stored copy.
+func (s *Server) AddImage(albumKey string, img *smugmug.Image, data []byte) *smugmug.Image {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.albums[albumKey]
+	if !ok {
+		panic("smugmugtest: unknown album " + albumKey)
+	}
+	cp := *img
+	return s.addImage(a, &cp, data).Image
+}
+
+// SetImageMetadata attaches EXIF metadata served by the image's !metadata
+// endpoint. Like Image, it accepts keys with a "-N" serial suffix.
+func (s *Server) SetImageMetadata(key string, md *smugmug.ImageMetadata) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.images[imageKey(key)]
+	if !ok {
+		panic("smugmugtest: unknown image " + key)
+	}
+	cp := *md
+	i.metadata = &cp
+}
+
+// AddComment stores c on image key and returns the stored copy. A zero
+// Date is set to the current time.
+func (s *Server) AddComment(key string, c *smugmug.Comment) *smugmug.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.images[imageKey(key)]
+	if !ok {
+		panic("smugmugtest: unknown image " + key)
+	}
+	return s.addComment(i, c).Comment
+}
+
+// SetFeatured marks album albumKey as featured, or not, on its owner's
+// UserFeaturedAlbums.
+func (s *Server) SetFeatured(albumKey string, featured bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.albums[albumKey]
+	if !ok {
+		panic("smugmugtest: unknown album " + albumKey)
+	}
+	a.featured = featured
+}
+
+// SetDownloadPassword protects the zip downloads of album albumKey with
+// password.
+func (s *Server) SetDownloadPassword(albumKey, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.albums[albumKey]
+	if !ok {
+		panic("smugmugtest: unknown album " + albumKey)
+	}
+	a.downloadPassword = password
+	a.HasDownloadPassword = password != ""
+}
+
+// SetZipPartSize splits album zip downloads into parts of at most n images.
+// Zero, the default, puts every image in one part.
+func (s *Server) SetZipPartSize(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.zipPartSize = n
+}
+
+// SetZipListDelay makes the first n status checks of every album zip
+// download list no parts, as SmugMug does while it queues the archive. The
+// parts then turn Ready on the check after the first one that lists them.
+func (s *Server) SetZipListDelay(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.zipListDelay = n
+}
+
+// SetViews sets the view count UserPopularMedia orders images by.
+func (s *Server) SetViews(key string, views int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.images[imageKey(key)]
+	if !ok {
+		panic("smugmugtest: unknown image " + key)
+	}
+	i.views = views
+}
+
+// Node returns the stored node with the given ID, or nil.
+func (s *Server) Node(id string) *smugmug.Node {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n, ok := s.nodes[id]; ok {
+		return n.Node
+	}
+	return nil
+}
+
+// Album returns the stored album with the given key, or nil.
+func (s *Server) Album(key string) *smugmug.Album {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok := s.albums[key]; ok {
+		return a.Album
+	}
+	return nil
+}
+
+// Image returns the stored image with the given key, or nil.
+func (s *Server) Image(key string) *smugmug.Image {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i, ok := s.images[imageKey(key)]; ok {
+		return i.Image
+	}
+	return nil
+}
+
+// ImageData returns the file contents of an image.
+func (s *Server) ImageData(key string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i, ok := s.images[imageKey(key)]; ok {
+		return i.data
+	}
+	return nil
+}
+
+// Requests returns how many requests with the given method the server has
+// received.
+func (s *Server) Requests(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[method]
+}
+
+func (s *Server) mustNode(id string) *node {
+	n, ok := s.nodes[id]
+	if !ok {
+		panic("smugmugtest: unknown node " + id)
+	}
+	return n
+}
+
+func (s *Server) nextKey(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s%05X", prefix, s.seq)
+}
+
+func (s *Server) addNode(parent *node, n *smugmug.Node) *node {
+	n.NodeID = s.nextKey("n")
+	if n.Type == "" {
+		n.Type = "Folder"
+	}
+	if n.Privacy == "" {
+		n.Privacy = "Public"
+	}
+	ret := &node{Node: n}
+	if parent != nil {
+		if n.URLName == "" {
+			n.URLName = smugmug.URLName(n.Name)
+		}
+		n.URLPath = parent.URLPath + "/" + n.URLName
+		ret.parentID = parent.NodeID
+		ret.owner = parent.owner
+		parent.children = append(parent.children, n.NodeID)
+		parent.HasChildren = true
+	}
+	s.nodes[n.NodeID] = ret
+	if n.Type == "Album" {
+		key := s.nextKey("a")
+		s.albums[key] = &album{
+			Album: &smugmug.Album{
+				AlbumKey: key,
+				Name:     n.Name,
+				NodeID:   n.NodeID,
+				Privacy:  n.Privacy,
+				URLName:  n.URLName,
+				URLPath:  n.URLPath,
+			},
+			owner:  ret.owner,
+			nodeID: n.NodeID,
+		}
+		ret.albumKey = key
+	}
+	return ret
+}
+
+func (s *Server) addImage(a *album, img *smugmug.Image, data []byte) *image {
+	sum := md5.Sum(data)
+	img.ImageKey = s.nextKey("i")
+	img.ArchivedMD5 = hex.EncodeToString(sum[:])
+	img.ArchivedSize = len(data)
+	img.OriginalSize = len(data)
+	if img.Format == "" {
+		img.Format = strings.ToUpper(strings.TrimPrefix(extension(img.FileName), "."))
+	}
+	i := &image{Image: img, owner: a.owner, albumKey: a.AlbumKey, data: data}
+	s.images[img.ImageKey] = i
+	a.images = append(a.images, img.ImageKey)
+	a.ImageCount = len(a.images)
+	return i
+}
+
+// taken returns when the image was captured, from its metadata.
+func (i *image) taken() *time.Time {
+	if i.metadata == nil || i.metadata.DateTimeCreated == nil {
+		return nil
+	}
+	return &i.metadata.DateTimeCreated.Time
+}
+
+func (s *Server) addComment(i *image, c *smugmug.Comment) *comment {
+	cp := *c
+	if cp.Date == nil {
+		now := time.Now().UTC().Truncate(time.Second)
+		cp.Date = &now
+	}
+	id := s.nextKey("c")
+	cp.URI = apiPrefix + "/comment/" + id
+	ret := &comment{Comment: &cp, imageKey: i.ImageKey}
+	s.comments[id] = ret
+	i.comments = append(i.comments, id)
+	return ret
+}
+
+func (s *Server) link(a *album, key string) {
+	if !contains(a.images, key) {
+		a.images = append(a.images, key)
+		a.ImageCount = len(a.images)
+	}
+}
+
+func (s *Server) unlink(a *album, key string) {
+	for n, k := range a.images {
+		if k == key {
+			a.images = append(a.images[:n:n], a.images[n+1:]...)
+			a.ImageCount = len(a.images)
+			return
+		}
+	}
+}
+
+// deleteImage removes i from every album that holds it.
+func (s *Server) deleteImage(i *image) {
+	for _, a := range s.albums {
+		s.unlink(a, i.ImageKey)
+	}
+	for _, id := range i.comments {
+		delete(s.comments, id)
+	}
+	delete(s.images, i.ImageKey)
+}
+
+func (s *Server) renderComment(c *comment) *smugmug.Comment {
+	cp := *c.Comment
+	cp.WebURI = s.URL + "/i-" + c.imageKey
+	cp.ResponseLevel = "Full"
+	return &cp
+}
+
+func (s *Server) addGrant(n *node, g *smugmug.Grant) *grant {
+	cp := *g
+	now := time.Now().UTC().Truncate(time.Second)
+	cp.DateAdded = &now
+	id := s.nextKey("g")
+	cp.URI = apiPrefix + "/grant/" + id
+	ret := &grant{Grant: &cp, owner: n.owner, nodeID: n.NodeID}
+	s.grants[id] = ret
+	return ret
+}
+
+// grantsWhere returns the grants matching keep in the order they were made.
+func (s *Server) grantsWhere(keep func(*grant) bool) []*grant {
+	var ret []*grant
+	for _, g := range s.grants {
+		if keep(g) {
+			ret = append(ret, g)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].URI < ret[j].URI })
+	return ret
+}
+
+func (s *Server) renderGrant(g *grant) *smugmug.Grant {
+	cp := *g.Grant
+	cp.WebURI = s.URL + s.nodes[g.nodeID].URLPath
+	cp.ResponseLevel = "Full"
+	return &cp
+}
+
+// userAlbums returns the albums owned by nickname in creation order.
+func (s *Server) userAlbums(nickname string) []*album {
+	var ret []*album
+	for _, a := range s.albums {
+		if a.owner == nickname {
+			ret = append(ret, a)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].AlbumKey < ret[j].AlbumKey })
+	return ret
+}
+
+// sortedChildren returns the children of n in the order SmugMug lists them:
+// by SortIndex when the folder sorts that way, otherwise as created.
+func (s *Server) sortedChildren(n *node) []*node {
+	children := make([]*node, len(n.children))
+	for i, id := range n.children {
+		children[i] = s.nodes[id]
+	}
+	if n.SortMethod == "SortIndex" {
+		desc := n.SortDirection == "Descending"
+		sort.SliceStable(children, func(i, j int) bool {
+			if desc {
+				return children[i].SortIndex > children[j].SortIndex
+			}
+			return children[i].SortIndex < children[j].SortIndex
+		})
+	}
+	return children
+}
+
+// nodeAt returns nickname's node at urlPath, compared like Node.URLPath
+// without regard to case or a trailing slash, or nil.
+func (s *Server) nodeAt(nickname, urlPath string) *node {
+	urlPath = strings.TrimRight(urlPath, "/")
+	for _, n := range s.nodes {
+		if n.owner == nickname && strings.EqualFold(n.URLPath, urlPath) {
+			return n
+		}
+	}
+	return nil
+}
+
+// userImages returns the images owned by nickname, newest first.
+func (s *Server) userImages(nickname string) []*image {
+	var ret []*image
+	for _, i := range s.images {
+		if i.owner == nickname {
+			ret = append(ret, i)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].ImageKey > ret[j].ImageKey })
+	return ret
+}
+
+func (s *Server) renderUser(u *user) *smugmug.User {
+	cp := *u.User
+	cp.URI = apiPrefix + "/user/" + cp.NickName
+	cp.WebURI = s.URL
+	cp.ResponseLevel = "Full"
+	return &cp
+}
+
+func (s *Server) renderNode(n *node) *smugmug.Node {
+	cp := *n.Node
+	cp.URI = apiPrefix + "/node/" + cp.NodeID
+	cp.WebURI = s.URL + cp.URLPath
+	cp.ResponseLevel = "Full"
+	return &cp
+}
+
+func (s *Server) renderAlbum(a *album) *smugmug.Album {
+	cp := *a.Album
+	cp.URI = apiPrefix + "/album/" + cp.AlbumKey
+	cp.WebURI = s.URL + cp.URLPath
+	cp.ResponseLevel = "Full"
+	return &cp
+}
+
+func (s *Server) renderImage(i *image) *smugmug.Image {
+	cp := *i.Image
+	cp.URI = imageURI(i)
+	cp.WebURI = s.URL + "/i-" + cp.ImageKey
+	cp.ArchivedURI = s.photoURL(i, "O")
+	cp.ThumbnailURL = s.photoURL(i, "Th")
+	cp.ResponseLevel = "Full"
+	return &cp
+}
+
+func (s *Server) renderSizes(i *image) *smugmug.ImageSizes {
+	return &smugmug.ImageSizes{
+		LargestImageURL:  s.photoURL(i, "O"),
+		OriginalImageURL: s.photoURL(i, "O"),
+		ThumbImageURL:    s.photoURL(i, "Th"),
+		URI:              imageURI(i) + "!sizes",
+	}
+}
+
+func (s *Server) renderSizeDetails(i *image) *smugmug.ImageSizeDetails {
+	return &smugmug.ImageSizeDetails{
+		ImageSizeOriginal: s.renderSize(i, "O"),
+		ImageSizeThumb:    s.renderSize(i, "Th"),
+		ImageURLTemplate:  s.URL + "/photos/" + i.ImageKey + "/0/#size#/" + i.ImageKey + "-#size#" + extension(i.FileName),
+		UsableSizes:       []string{"ImageSizeOriginal", "ImageSizeThumb"},
+		URI:               imageURI(i) + "!sizedetails",
+	}
+}
+
+func (s *Server) renderSize(i *image, size string) *smugmug.ImageSize {
+	return &smugmug.ImageSize{
+		URL:    s.photoURL(i, size),
+		Ext:    strings.TrimPrefix(extension(i.FileName), "."),
+		Height: i.OriginalHeight,
+		Width:  i.OriginalWidth,
+		Size:   len(i.data),
+	}
+}
+
+func (s *Server) renderLargest(i *image) *smugmug.LargestImage {
+	size := s.renderSize(i, "O")
+	return &smugmug.LargestImage{
+		Ext:    size.Ext,
+		Height: size.Height,
+		Size:   size.Size,
+		URL:    size.URL,
+		Usable: true,
+		Width:  size.Width,
+		URI:    imageURI(i) + "!largestimage",
+	}
+}
+
+func (s *Server) photoURL(i *image, size string) string {
+	return s.URL + "/photos/" + i.ImageKey + "/0/" + size + "/" + i.ImageKey + "-" + size + extension(i.FileName)
+}
+
+func imageURI(i *image) string {
+	return apiPrefix + "/image/" + i.ImageKey + "-0"
+}
+
+// links lists the related endpoints of a rendered object, i.e. its Uris.
+func (s *Server) links(obj interface{}) []link {
+	var ret []link
+	add := func(name, uri, locator, locatorType string) {
+		ret = append(ret, link{name: name, uri: uri, locator: locator, locatorType: locatorType})
+	}
+	switch v := obj.(type) {
+	case *smugmug.User:
+		if u, ok := s.users[v.NickName]; ok {
+			uri := apiPrefix + "/user/" + u.NickName
+			add("Node", apiPrefix+"/node/"+u.rootNodeID, "Node", "Object")
+			add("UserAlbums", uri+"!albums", "Album", "Objects")
+			add("UserFeaturedAlbums", uri+"!featuredalbums", "Album", "Objects")
+			add("UserPopularMedia", uri+"!popularmedia", "Image", "Objects")
+			add("UserRecentImages", uri+"!recentimages", "Image", "Objects")
+			add("UserImageSearch", uri+"!imagesearch", "Image", "Objects")
+			add("UserGrants", uri+"!grants", "Grant", "Objects")
+		}
+	case *smugmug.Node:
+		n, ok := s.nodes[v.NodeID]
+		if !ok {
+			break
+		}
+		if n.albumKey != "" {
+			add("Album", apiPrefix+"/album/"+n.albumKey, "Album", "Object")
+		}
+		if n.Type == "Folder" {
+			add("ChildNodes", apiPrefix+"/node/"+n.NodeID+"!children", "Node", "Objects")
+		}
+		if n.parentID != "" {
+			add("ParentNode", apiPrefix+"/node/"+n.parentID, "Node", "Object")
+			add("ParentNodes", apiPrefix+"/node/"+n.NodeID+"!parents", "Node", "Objects")
+		}
+		add("NodeGrants", apiPrefix+"/node/"+n.NodeID+"!grants", "Grant", "Objects")
+		add("User", apiPrefix+"/user/"+n.owner, "User", "Object")
+	case *smugmug.Album:
+		a, ok := s.albums[v.AlbumKey]
+		if !ok {
+			break
+		}
+		add("AlbumDownload", apiPrefix+"/album/"+a.AlbumKey+"!download", "Download", "Objects")
+		add("AlbumImages", apiPrefix+"/album/"+a.AlbumKey+"!images", "AlbumImage", "Objects")
+		add("AlbumShareUris", apiPrefix+"/album/"+a.AlbumKey+"!shareuris", "AlbumShareUris", "Object")
+		add("Node", apiPrefix+"/node/"+a.nodeID, "Node", "Object")
+		add("User", apiPrefix+"/user/"+a.owner, "User", "Object")
+	case *smugmug.Image:
+		i, ok := s.images[v.ImageKey]
+		if !ok {
+			break
+		}
+		uri := imageURI(i)
+		add("ImageAlbum", apiPrefix+"/album/"+i.albumKey, "Album", "Object")
+		add("ImageComments", uri+"!comments", "Comment", "Objects")
+		add("ImageDownload", uri+"!download", "ImageDownload", "Object")
+		if i.metadata != nil {
+			add("ImageMetadata", uri+"!metadata", "ImageMetadata", "Object")
+		}
+		add("ImageOwner", apiPrefix+"/user/"+i.owner, "User", "Object")
+		add("ImageSizeDetails", uri+"!sizedetails", "ImageSizeDetails", "Object")
+		add("ImageSizes", uri+"!sizes", "ImageSizes", "Object")
+		add("LargestImage", uri+"!largestimage", "LargestImage", "Object")
+	case *smugmug.Grant:
+		if g, ok := s.grants[strings.TrimPrefix(v.URI, apiPrefix+"/grant/")]; ok {
+			add("Node", apiPrefix+"/node/"+g.nodeID, "Node", "Object")
+		}
+	}
+	return ret
+}
+
+// linkify fills in the Uris of every object in v, in the short form when
+// _shorturis was requested and the long form otherwise.
+func (s *Server) linkify(v interface{}, short bool) {
+	for _, obj := range objects(v) {
+		uris := smugmug.URIs{}
+		for _, l := range s.links(obj) {
+			if short {
+				uris[l.name] = l.uri
+				continue
+			}
+			uris[l.name] = map[string]interface{}{
+				"Uri":            l.uri,
+				"Locator":        l.locator,
+				"LocatorType":    l.locatorType,
+				"UriDescription": l.name,
+				"EndpointType":   l.name,
+			}
+		}
+		switch o := obj.(type) {
+		case *smugmug.User:
+			o.URIs = &uris
+		case *smugmug.Node:
+			o.URIs = &uris
+		case *smugmug.Album:
+			o.URIs = &uris
+		case *smugmug.Image:
+			o.URIs = &uris
+		case *smugmug.Grant:
+			o.URIs = &uris
+		}
+	}
+}
+
+func objects(v interface{}) []interface{} {
+	if list, ok := v.([]interface{}); ok {
+		return list
+	}
+	return []interface{}{v}
+}
+
+func extension(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return strings.ToLower(name[i:])
+	}
+	return ".jpg"
+}
@@ -0,0 +1,48 @@
+package smugmugtest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+	"github.com/pilwon/go-smugmug/smugmugtest"
+)
+
+func TestSeedHelpersAcceptSerialKeys(t *testing.T) {
+	fake := smugmugtest.NewServer()
+	defer fake.Close()
+	u := fake.AddUser(&smugmug.User{NickName: "cmac"})
+	a := fake.AddAlbum(fake.RootNode(u.NickName).NodeID, &smugmug.Album{Name: "Paris"})
+	img := fake.AddImage(a.AlbumKey, &smugmug.Image{FileName: "a.jpg"}, []byte("a"))
+	key := img.ImageKey + "-0"
+
+	fake.SetImageMetadata(key, &smugmug.ImageMetadata{Title: "Eiffel Tower"})
+	fake.AddComment(key, &smugmug.Comment{Text: "nice"})
+	fake.SetViews(key, 7)
+	if got := fake.Image(key); got == nil || got.ImageKey != img.ImageKey {
+		t.Fatalf("Image(%q) = %+v", key, got)
+	}
+
+	s, err := fake.Service()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.Images.Metadata(img.ImageKey).Context(context.Background()).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ImageMetadata == nil || res.ImageMetadata.Title != "Eiffel Tower" {
+		t.Errorf("metadata = %+v", res.ImageMetadata)
+	}
+}
+
+func TestSeedHelpersPanicOnUnknownImage(t *testing.T) {
+	fake := smugmugtest.NewServer()
+	defer fake.Close()
+	defer func() {
+		if recover() == nil {
+			t.Error("SetImageMetadata did not panic")
+		}
+	}()
+	fake.SetImageMetadata("nope-0", &smugmug.ImageMetadata{})
+}
@@ -0,0 +1,1107 @@
+// Package smugmugtest provides an in-memory SmugMug API v2 server for tests.
+//
+//	fake := smugmugtest.NewServer()
+//	defer fake.Close()
+//	u := fake.AddUser(&smugmug.User{NickName: "cmac"})
+//	a := fake.AddAlbum(fake.RootNode(u.NickName).NodeID, &smugmug.Album{Name: "Paris"})
+//	fake.AddImage(a.AlbumKey, &smugmug.Image{FileName: "eiffel.jpg"}, jpeg)
+//
+//	s, _ := smugmug.New(fake.Client(), smugmug.WithBaseURL(fake.URL))
+//	res, err := s.Albums.Get(a.AlbumKey).Expand([]string{"Node"}).Do()
+package smugmugtest
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+const apiPrefix = "/api/v2"
+
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	seq      int
+	authUser string
+	users    map[string]*user
+	nodes    map[string]*node
+	albums   map[string]*album
+	images   map[string]*image
+	comments map[string]*comment
+	grants   map[string]*grant
+
+	zipPartSize  int
+	zipListDelay int
+	requests     map[string]int // by method
+}
+
+// NewServer starts a fake API server. The caller must Close it.
+func NewServer() *Server {
+	s := &Server{
+		users:    map[string]*user{},
+		nodes:    map[string]*node{},
+		albums:   map[string]*album{},
+		images:   map[string]*image{},
+		comments: map[string]*comment{},
+		grants:   map[string]*grant{},
+		requests: map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Service returns a client wired to the fake server.
+func (s *Server) Service() (*smugmug.Service, error) {
+	return smugmug.New(s.Client(), smugmug.WithBaseURL(s.URL))
+}
+
+// UploadURL is the fake equivalent of https://upload.smugmug.com/.
+func (s *Server) UploadURL() string {
+	return s.URL + "/"
+}
+
+type apiError struct {
+	code    int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d %s", e.code, e.message)
+}
+
+var (
+	errNotFound         = &apiError{http.StatusNotFound, "Not Found"}
+	errMethodNotAllowed = &apiError{http.StatusMethodNotAllowed, "Method Not Allowed"}
+	errUnauthorized     = &apiError{http.StatusUnauthorized, "Unauthorized"}
+)
+
+type pages struct {
+	Total          int
+	Start          int
+	Count          int
+	RequestedCount int
+	FirstPage      string `json:",omitempty"`
+	LastPage       string `json:",omitempty"`
+	NextPage       string `json:",omitempty"`
+	PrevPage       string `json:",omitempty"`
+}
+
+type result struct {
+	uri         string
+	locator     string
+	locatorType string
+	value       interface{}
+	pages       *pages
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests[r.Method]++
+
+	switch {
+	case r.URL.Path == "/" && r.Method == http.MethodPost:
+		s.serveUpload(w, r)
+		return
+	case strings.HasPrefix(r.URL.Path, "/photos/"):
+		s.servePhoto(w, r)
+		return
+	case strings.HasPrefix(r.URL.Path, "/zips/"):
+		s.serveZip(w, r)
+		return
+	case !strings.HasPrefix(r.URL.Path, apiPrefix):
+		writeError(w, errNotFound)
+		return
+	}
+
+	q := r.URL.Query()
+	var res *result
+	var err error
+	status := http.StatusOK
+	switch r.Method {
+	case http.MethodGet:
+		if q.Get("_multiuri") != "" {
+			res = s.lookupMulti(r.URL.Path, q)
+			break
+		}
+		res, err = s.lookup(r.URL.Path, q)
+	case http.MethodPost:
+		res, status, err = s.post(r.URL.Path, r.Body)
+	case http.MethodPatch:
+		res, err = s.patch(r.URL.Path, r.Body)
+	case http.MethodDelete:
+		res, err = s.delete(r.URL.Path)
+	default:
+		err = errMethodNotAllowed
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.writeResult(w, status, res, q)
+}
+
+func (s *Server) writeResult(w http.ResponseWriter, status int, res *result, q url.Values) {
+	short := q["_shorturis"] != nil
+	s.linkify(res.value, short)
+
+	response := map[string]interface{}{
+		"Uri":         res.uri,
+		"Locator":     res.locator,
+		"LocatorType": res.locatorType,
+	}
+	if res.locator != "" {
+		response[res.locator] = trim(res.value, splitList(q.Get("_filter")), splitList(q.Get("_filteruri")))
+	}
+	if res.pages != nil {
+		response["Pages"] = res.pages
+	}
+	body := map[string]interface{}{
+		"Code":     status,
+		"Message":  http.StatusText(status),
+		"Response": response,
+	}
+	if names := splitList(q.Get("_expand")); len(names) > 0 {
+		body["Expansions"] = s.expand(res.value, names, short)
+	}
+	writeJSON(w, status, body)
+}
+
+// expand resolves the named links of every object in v, keyed by URI the way
+// the API reports them.
+func (s *Server) expand(v interface{}, names []string, short bool) map[string]interface{} {
+	exp := map[string]interface{}{}
+	for _, obj := range objects(v) {
+		for _, l := range s.links(obj) {
+			if !contains(names, l.name) {
+				continue
+			}
+			if _, ok := exp[l.uri]; ok {
+				continue
+			}
+			u, _ := url.Parse(l.uri)
+			res, err := s.lookup(u.Path, u.Query())
+			if err != nil {
+				continue
+			}
+			s.linkify(res.value, short)
+			payload := map[string]interface{}{res.locator: res.value}
+			if res.pages != nil {
+				payload["Pages"] = res.pages
+			}
+			exp[l.uri] = payload
+		}
+	}
+	return exp
+}
+
+func (s *Server) lookup(path string, q url.Values) (*result, error) {
+	if path == apiPrefix+"!weburilookup" {
+		return s.lookupWebURI(path, q.Get("WebUri"))
+	}
+	if path == apiPrefix+"!authuser" {
+		if s.authUser == "" {
+			return nil, errUnauthorized
+		}
+		path = apiPrefix + "/user/" + s.authUser
+	}
+	rel := strings.TrimPrefix(path, apiPrefix+"/")
+	rel, action := splitAction(rel)
+	parts := strings.Split(rel, "/")
+
+	switch {
+	case len(parts) == 2 && parts[0] == "user":
+		u, ok := s.users[parts[1]]
+		if !ok {
+			return nil, errNotFound
+		}
+		switch action {
+		case "":
+			return object(path, "User", s.renderUser(u)), nil
+		case "albums", "featuredalbums":
+			var albums []interface{}
+			for _, a := range s.userAlbums(u.NickName) {
+				if action == "albums" || a.featured {
+					albums = append(albums, s.renderAlbum(a))
+				}
+			}
+			return collection(path, "Album", albums, q), nil
+		case "recentimages", "popularmedia":
+			list := s.userImages(u.NickName)
+			if action == "popularmedia" {
+				sort.SliceStable(list, func(i, j int) bool { return list[i].views > list[j].views })
+			}
+			var images []interface{}
+			for _, i := range list {
+				images = append(images, s.renderImage(i))
+			}
+			return collection(path, "Image", images, q), nil
+		case "grants":
+			var grants []interface{}
+			for _, g := range s.grantsWhere(func(g *grant) bool { return g.owner == u.NickName }) {
+				grants = append(grants, s.renderGrant(g))
+			}
+			return collection(path, "Grant", grants, q), nil
+		case "urlpathlookup":
+			n := s.nodeAt(u.NickName, q.Get("urlpath"))
+			if n == nil {
+				return nil, errNotFound
+			}
+			return s.nodeObject(path, n), nil
+		case "imagesearch":
+			list, err := s.searchImages(u.NickName, q)
+			if err != nil {
+				return nil, err
+			}
+			var images []interface{}
+			for _, i := range list {
+				images = append(images, s.renderImage(i))
+			}
+			return collection(path, "Image", images, q), nil
+		}
+
+	case len(parts) == 2 && parts[0] == "node":
+		n, ok := s.nodes[parts[1]]
+		if !ok {
+			return nil, errNotFound
+		}
+		switch action {
+		case "":
+			return object(path, "Node", s.renderNode(n)), nil
+		case "children":
+			var children []interface{}
+			for _, c := range s.sortedChildren(n) {
+				children = append(children, s.renderNode(c))
+			}
+			return collection(path, "Node", children, q), nil
+		case "parents":
+			var parents []interface{}
+			for p := s.nodes[n.parentID]; p != nil; p = s.nodes[p.parentID] {
+				parents = append(parents, s.renderNode(p))
+			}
+			return collection(path, "Node", parents, q), nil
+		case "grants":
+			var grants []interface{}
+			for _, g := range s.grantsWhere(func(g *grant) bool { return g.nodeID == n.NodeID }) {
+				grants = append(grants, s.renderGrant(g))
+			}
+			return collection(path, "Grant", grants, q), nil
+		}
+
+	case len(parts) == 2 && parts[0] == "album":
+		a, ok := s.albums[parts[1]]
+		if !ok {
+			return nil, errNotFound
+		}
+		switch action {
+		case "":
+			return object(path, "Album", s.renderAlbum(a)), nil
+		case "images":
+			var images []interface{}
+			for _, key := range a.images {
+				images = append(images, s.renderImage(s.images[key]))
+			}
+			return collection(path, "AlbumImage", images, q), nil
+		case "download":
+			var parts []interface{}
+			if d := a.download; d != nil {
+				if d.checks++; d.checks <= s.zipListDelay {
+					return collection(path, "Download", nil, q), nil
+				}
+				if d.checks > s.zipListDelay+1 {
+					for n, p := range d.parts {
+						p.Status = "Ready"
+						p.Size = int64(len(d.data[n]))
+						p.URL = fmt.Sprintf("%s/zips/%s/%d", s.URL, a.AlbumKey, n)
+					}
+				}
+				for _, p := range d.parts {
+					cp := *p
+					parts = append(parts, &cp)
+				}
+			}
+			return collection(path, "Download", parts, q), nil
+		case "shareuris":
+			web := s.URL + a.URLPath
+			return object(path, "AlbumShareUris", &smugmug.AlbumShareURIs{
+				PublicURL:   web,
+				ShortURL:    s.URL + "/s/" + a.AlbumKey,
+				UnlistedURL: web + "/n-" + a.nodeID,
+			}), nil
+		}
+
+	case len(parts) == 4 && parts[0] == "album" && parts[2] == "image":
+		a, ok := s.albums[parts[1]]
+		if !ok || action != "" || !contains(a.images, imageKey(parts[3])) {
+			return nil, errNotFound
+		}
+		i := s.images[imageKey(parts[3])]
+		return object(path, "AlbumImage", s.renderImage(i)), nil
+
+	case len(parts) == 2 && parts[0] == "image":
+		i, ok := s.images[imageKey(parts[1])]
+		if !ok {
+			return nil, errNotFound
+		}
+		switch action {
+		case "":
+			return object(path, "Image", s.renderImage(i)), nil
+		case "metadata":
+			if i.metadata == nil {
+				return nil, errNotFound
+			}
+			md := *i.metadata
+			md.URI = path
+			return object(path, "ImageMetadata", &md), nil
+		case "sizes":
+			return object(path, "ImageSizes", s.renderSizes(i)), nil
+		case "sizedetails":
+			return object(path, "ImageSizeDetails", s.renderSizeDetails(i)), nil
+		case "comments":
+			var comments []interface{}
+			for _, id := range i.comments {
+				comments = append(comments, s.renderComment(s.comments[id]))
+			}
+			return collection(path, "Comment", comments, q), nil
+		case "largestimage":
+			return object(path, "LargestImage", s.renderLargest(i)), nil
+		case "download":
+			return object(path, "ImageDownload", &smugmug.ImageDownload{URL: s.photoURL(i, "O"), URI: path}), nil
+		}
+	}
+	return nil, errNotFound
+}
+
+// lookupMulti answers a _multiuri request with every listed object that
+// exists; missing ones are left out.
+func (s *Server) lookupMulti(path string, q url.Values) *result {
+	kind := strings.Trim(strings.TrimPrefix(path, apiPrefix), "/")
+	locator := map[string]string{"album": "Album", "image": "Image", "node": "Node", "user": "User"}[kind]
+	items := []interface{}{}
+	for _, uri := range splitList(q.Get("_multiuri")) {
+		u, err := url.Parse(uri)
+		if err != nil {
+			continue
+		}
+		res, err := s.lookup(u.Path, u.Query())
+		if err != nil || res.locatorType != "Object" {
+			continue
+		}
+		locator = res.locator
+		items = append(items, res.value)
+	}
+	return &result{uri: path, locator: locator, locatorType: "Objects", value: items}
+}
+
+func (s *Server) post(path string, body io.Reader) (*result, int, error) {
+	rel, action := splitAction(strings.TrimPrefix(path, apiPrefix+"/"))
+	parts := strings.Split(rel, "/")
+	switch {
+	case len(parts) == 2 && parts[0] == "node" && action == "children":
+		res, err := s.createNode(path, parts[1], body)
+		return res, http.StatusCreated, err
+	case len(parts) == 2 && parts[0] == "album" && action == "download":
+		res, err := s.requestDownload(path, parts[1], body)
+		return res, http.StatusOK, err
+	case len(parts) == 2 && parts[0] == "album" && action != "":
+		res, err := s.albumAction(path, parts[1], action, body)
+		return res, http.StatusOK, err
+	case len(parts) == 2 && parts[0] == "image" && action == "comments":
+		res, err := s.postComment(parts[1], body)
+		return res, http.StatusCreated, err
+	case len(parts) == 2 && parts[0] == "node" && action == "grants":
+		res, err := s.postGrant(parts[1], body)
+		return res, http.StatusCreated, err
+	}
+	return nil, 0, errMethodNotAllowed
+}
+
+func (s *Server) createNode(path, parentID string, body io.Reader) (*result, error) {
+	parent, ok := s.nodes[parentID]
+	if !ok {
+		return nil, errNotFound
+	}
+	n := &smugmug.Node{}
+	if err := json.NewDecoder(body).Decode(n); err != nil {
+		return nil, &apiError{http.StatusBadRequest, err.Error()}
+	}
+	if parent.Type != "Folder" {
+		return nil, &apiError{http.StatusBadRequest, "parent is not a folder"}
+	}
+	if n.Name == "" {
+		return nil, &apiError{http.StatusBadRequest, "Name is required"}
+	}
+	switch n.Type {
+	case "Folder", "Album", "Page":
+	default:
+		return nil, &apiError{http.StatusBadRequest, "invalid Type " + strconv.Quote(string(n.Type))}
+	}
+	if n.URLName == "" {
+		n.URLName = smugmug.URLName(n.Name)
+	}
+	for _, id := range parent.children {
+		if strings.EqualFold(s.nodes[id].URLName, n.URLName) {
+			return nil, &apiError{http.StatusConflict, "Conflict"}
+		}
+	}
+	created := s.addNode(parent, n)
+	return object(apiPrefix+"/node/"+created.NodeID, "Node", s.renderNode(created)), nil
+}
+
+// albumAction implements the album!moveimages, !collectimages and
+// !deleteimages actions.
+func (s *Server) albumAction(path, albumKey, action string, body io.Reader) (*result, error) {
+	a, ok := s.albums[albumKey]
+	if !ok {
+		return nil, errNotFound
+	}
+	param := map[string]string{
+		"moveimages":    "MoveUris",
+		"collectimages": "CollectUris",
+		"deleteimages":  "AlbumImageUris",
+		"sortimages":    "MoveUris",
+	}[action]
+	if param == "" {
+		return nil, errMethodNotAllowed
+	}
+	req := map[string]string{}
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		return nil, &apiError{http.StatusBadRequest, err.Error()}
+	}
+	var images []*image
+	for _, uri := range splitList(req[param]) {
+		i, from, err := s.albumImage(uri)
+		if err != nil {
+			return nil, err
+		}
+		if (action == "deleteimages" || action == "sortimages") && from != a {
+			return nil, &apiError{http.StatusBadRequest, uri + " is not in this album"}
+		}
+		images = append(images, i)
+	}
+	if len(images) == 0 {
+		return nil, &apiError{http.StatusBadRequest, param + " is required"}
+	}
+	if action == "sortimages" {
+		return &result{uri: path}, s.sortImages(a, images, req["MoveLocation"], req["Target"])
+	}
+	for _, i := range images {
+		switch action {
+		case "moveimages":
+			s.unlink(s.albums[i.albumKey], i.ImageKey)
+			i.albumKey = a.AlbumKey
+			s.link(a, i.ImageKey)
+		case "collectimages":
+			s.link(a, i.ImageKey)
+		case "deleteimages":
+			s.unlink(a, i.ImageKey)
+			if i.albumKey == a.AlbumKey {
+				s.deleteImage(i)
+			}
+		}
+	}
+	return &result{uri: path}, nil
+}
+
+// sortImages moves images, in order, next to the album image target.
+func (s *Server) sortImages(a *album, images []*image, location, target string) error {
+	t, from, err := s.albumImage(target)
+	if err != nil {
+		return err
+	}
+	if from != a {
+		return &apiError{http.StatusBadRequest, "Target is not in this album"}
+	}
+	moved := map[string]bool{}
+	var keys []string
+	for _, i := range images {
+		moved[i.ImageKey] = true
+		keys = append(keys, i.ImageKey)
+	}
+	if moved[t.ImageKey] {
+		return &apiError{http.StatusBadRequest, "Target cannot be moved"}
+	}
+	var rest []string
+	for _, key := range a.images {
+		if !moved[key] {
+			rest = append(rest, key)
+		}
+	}
+	at := indexOf(rest, t.ImageKey)
+	switch location {
+	case "Before":
+	case "After":
+		at++
+	default:
+		return &apiError{http.StatusBadRequest, "MoveLocation must be Before or After"}
+	}
+	a.images = append(append(append([]string{}, rest[:at]...), keys...), rest[at:]...)
+	a.SortMethod = "Position"
+	return nil
+}
+
+// albumImage resolves an album image URI, or a plain image URI, to the image
+// and the album it was addressed through.
+func (s *Server) albumImage(uri string) (*image, *album, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, nil, &apiError{http.StatusBadRequest, err.Error()}
+	}
+	parts := strings.Split(strings.TrimPrefix(u.Path, apiPrefix+"/"), "/")
+	switch {
+	case len(parts) == 4 && parts[0] == "album" && parts[2] == "image":
+		a, ok := s.albums[parts[1]]
+		if !ok || !contains(a.images, imageKey(parts[3])) {
+			return nil, nil, errNotFound
+		}
+		return s.images[imageKey(parts[3])], a, nil
+	case len(parts) == 2 && parts[0] == "image":
+		i, ok := s.images[imageKey(parts[1])]
+		if !ok {
+			return nil, nil, errNotFound
+		}
+		return i, s.albums[i.albumKey], nil
+	}
+	return nil, nil, &apiError{http.StatusBadRequest, "not an image URI: " + uri}
+}
+
+// patch applies the fields in body to an image, leaving the rest alone.
+func (s *Server) patch(path string, body io.Reader) (*result, error) {
+	rel, action := splitAction(strings.TrimPrefix(path, apiPrefix+"/"))
+	parts := strings.Split(rel, "/")
+	if len(parts) != 2 || action != "" {
+		return nil, errMethodNotAllowed
+	}
+	switch parts[0] {
+	case "image":
+		i, ok := s.images[imageKey(parts[1])]
+		if !ok {
+			return nil, errNotFound
+		}
+		patched := &smugmug.Image{}
+		if err := patchFields(i.Image, patched, body, "Title", "Caption", "Keywords", "Hidden", "Latitude", "Longitude", "Altitude"); err != nil {
+			return nil, err
+		}
+		*i.Image = *patched
+		return object(apiPrefix+"/image/"+i.ImageKey+"-0", "Image", s.renderImage(i)), nil
+	case "node":
+		n, ok := s.nodes[parts[1]]
+		if !ok {
+			return nil, errNotFound
+		}
+		patched := &smugmug.Node{}
+		if err := patchFields(n.Node, patched, body, "Name", "Description", "Privacy", "SortDirection", "SortIndex", "SortMethod", "UrlName"); err != nil {
+			return nil, err
+		}
+		*n.Node = *patched
+		return object(apiPrefix+"/node/"+n.NodeID, "Node", s.renderNode(n)), nil
+	}
+	return nil, errMethodNotAllowed
+}
+
+// patchFields decodes the changes in body over a copy of cur into patched,
+// refusing fields outside allowed.
+func patchFields(cur, patched interface{}, body io.Reader, allowed ...string) error {
+	changes := map[string]json.RawMessage{}
+	if err := json.NewDecoder(body).Decode(&changes); err != nil {
+		return &apiError{http.StatusBadRequest, err.Error()}
+	}
+	data, _ := json.Marshal(cur)
+	fields := map[string]json.RawMessage{}
+	json.Unmarshal(data, &fields)
+	for k, v := range changes {
+		if !contains(allowed, k) {
+			return &apiError{http.StatusBadRequest, k + " cannot be changed"}
+		}
+		fields[k] = v
+	}
+	data, _ = json.Marshal(fields)
+	if err := json.Unmarshal(data, patched); err != nil {
+		return &apiError{http.StatusBadRequest, err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) postComment(key string, body io.Reader) (*result, error) {
+	i, ok := s.images[imageKey(key)]
+	if !ok {
+		return nil, errNotFound
+	}
+	c := &smugmug.Comment{}
+	if err := json.NewDecoder(body).Decode(c); err != nil {
+		return nil, &apiError{http.StatusBadRequest, err.Error()}
+	}
+	if c.Text == "" {
+		return nil, &apiError{http.StatusBadRequest, "Text is required"}
+	}
+	if c.Rating < 0 || c.Rating > 5 {
+		return nil, &apiError{http.StatusBadRequest, "Rating must be between 0 and 5"}
+	}
+	c.Date = nil
+	created := s.addComment(i, c)
+	return object(created.URI, "Comment", s.renderComment(created)), nil
+}
+
+func (s *Server) requestDownload(path, albumKey string, body io.Reader) (*result, error) {
+	a, ok := s.albums[albumKey]
+	if !ok {
+		return nil, errNotFound
+	}
+	var req struct{ Password string }
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		return nil, &apiError{http.StatusBadRequest, err.Error()}
+	}
+	if !a.AllowDownloads {
+		return nil, &apiError{http.StatusForbidden, "Downloads are not allowed"}
+	}
+	if a.downloadPassword != "" && req.Password != a.downloadPassword {
+		return nil, &apiError{http.StatusForbidden, "Wrong download password"}
+	}
+	keys := a.images
+	size := s.zipPartSize
+	if size <= 0 || size > len(keys) {
+		size = len(keys)
+	}
+	d := &zipDownload{}
+	for n := 0; len(keys) > 0; n++ {
+		chunk := keys
+		if len(chunk) > size {
+			chunk = chunk[:size]
+		}
+		keys = keys[len(chunk):]
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for _, key := range chunk {
+			i := s.images[key]
+			f, err := zw.Create(i.FileName)
+			if err != nil {
+				return nil, err
+			}
+			f.Write(i.data)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		d.data = append(d.data, buf.Bytes())
+		d.parts = append(d.parts, &smugmug.AlbumDownload{
+			FileName: fmt.Sprintf("%s-%d.zip", a.URLName, n+1),
+			Status:   "Processing",
+			URI:      path,
+		})
+	}
+	a.download = d
+	var parts []interface{}
+	for _, p := range d.parts {
+		cp := *p
+		parts = append(parts, &cp)
+	}
+	return collection(path, "Download", parts, url.Values{}), nil
+}
+
+func (s *Server) serveZip(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/zips/"), "/")
+	a, ok := s.albums[parts[0]]
+	if !ok || a.download == nil || len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	n, err := strconv.Atoi(parts[1])
+	if err != nil || n < 0 || n >= len(a.download.data) {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Length", strconv.Itoa(len(a.download.data[n])))
+	w.Write(a.download.data[n])
+}
+
+func (s *Server) postGrant(nodeID string, body io.Reader) (*result, error) {
+	n, ok := s.nodes[nodeID]
+	if !ok {
+		return nil, errNotFound
+	}
+	g := &smugmug.Grant{}
+	if err := json.NewDecoder(body).Decode(g); err != nil {
+		return nil, &apiError{http.StatusBadRequest, err.Error()}
+	}
+	if !strings.Contains(g.Email, "@") {
+		return nil, &apiError{http.StatusBadRequest, "Email is required"}
+	}
+	dup := s.grantsWhere(func(old *grant) bool {
+		return old.nodeID == n.NodeID && strings.EqualFold(old.Email, g.Email)
+	})
+	if len(dup) > 0 {
+		return nil, &apiError{http.StatusConflict, "Conflict"}
+	}
+	created := s.addGrant(n, g)
+	return object(created.URI, "Grant", s.renderGrant(created)), nil
+}
+
+func (s *Server) delete(path string) (*result, error) {
+	rel, action := splitAction(strings.TrimPrefix(path, apiPrefix+"/"))
+	parts := strings.Split(rel, "/")
+	if len(parts) == 2 && parts[0] == "grant" && action == "" {
+		if _, ok := s.grants[parts[1]]; !ok {
+			return nil, errNotFound
+		}
+		delete(s.grants, parts[1])
+		return &result{uri: path}, nil
+	}
+	if len(parts) == 2 && parts[0] == "comment" && action == "" {
+		c, ok := s.comments[parts[1]]
+		if !ok {
+			return nil, errNotFound
+		}
+		i := s.images[c.imageKey]
+		for n, id := range i.comments {
+			if id == parts[1] {
+				i.comments = append(i.comments[:n:n], i.comments[n+1:]...)
+				break
+			}
+		}
+		delete(s.comments, parts[1])
+		return &result{uri: path}, nil
+	}
+	if len(parts) != 2 || parts[0] != "image" || action != "" {
+		return nil, errMethodNotAllowed
+	}
+	i, ok := s.images[imageKey(parts[1])]
+	if !ok {
+		return nil, errNotFound
+	}
+	s.deleteImage(i)
+	return &result{uri: path}, nil
+}
+
+func (s *Server) serveUpload(w http.ResponseWriter, r *http.Request) {
+	fail := func(msg string) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"stat": "fail", "method": "smugmug.images.upload", "code": 5, "message": msg,
+		})
+	}
+	u, err := url.Parse(r.Header.Get("X-Smug-AlbumUri"))
+	if err != nil {
+		fail("invalid X-Smug-AlbumUri")
+		return
+	}
+	a, ok := s.albums[strings.TrimPrefix(u.Path, apiPrefix+"/album/")]
+	if !ok {
+		fail("album not found")
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		fail(err.Error())
+		return
+	}
+	sum := md5.Sum(data)
+	if want := r.Header.Get("Content-MD5"); want != "" && !strings.EqualFold(want, hex.EncodeToString(sum[:])) {
+		fail("MD5 mismatch")
+		return
+	}
+	img := &smugmug.Image{
+		FileName: r.Header.Get("X-Smug-FileName"),
+		Title:    r.Header.Get("X-Smug-Title"),
+		Caption:  r.Header.Get("X-Smug-Caption"),
+		Keywords: r.Header.Get("X-Smug-Keywords"),
+	}
+	i := s.addImage(a, img, data)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"stat":   "ok",
+		"method": "smugmug.images.upload",
+		"Image": map[string]string{
+			"ImageUri":      imageURI(i),
+			"AlbumImageUri": apiPrefix + "/album/" + a.AlbumKey + "/image/" + i.ImageKey + "-0",
+			"URL":           s.URL + "/i-" + i.ImageKey,
+		},
+	})
+}
+
+func (s *Server) servePhoto(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/photos/"), "/")
+	i, ok := s.images[parts[0]]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(i.data)))
+	w.Write(i.data)
+}
+
+// trim applies _filter and _filteruri to the objects in v. Uri and Uris
+// survive _filter as they do on the real API.
+func trim(v interface{}, fields, uris []string) interface{} {
+	if fields == nil && uris == nil {
+		return v
+	}
+	if list, ok := v.([]interface{}); ok {
+		ret := make([]interface{}, len(list))
+		for i, obj := range list {
+			ret[i] = trim(obj, fields, uris)
+		}
+		return ret
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return v
+	}
+	for k := range obj {
+		if fields != nil && k != "Uri" && k != "Uris" && !contains(fields, k) {
+			delete(obj, k)
+		}
+	}
+	if links, ok := obj["Uris"].(map[string]interface{}); ok && uris != nil {
+		for k := range links {
+			if !contains(uris, k) {
+				delete(links, k)
+			}
+		}
+	}
+	return obj
+}
+
+func object(uri, locator string, v interface{}) *result {
+	return &result{uri: uri, locator: locator, locatorType: "Object", value: v}
+}
+
+// nodeObject renders n as the album it backs, if any, or as a node.
+func (s *Server) nodeObject(uri string, n *node) *result {
+	if n.albumKey != "" {
+		return object(uri, "Album", s.renderAlbum(s.albums[n.albumKey]))
+	}
+	return object(uri, "Node", s.renderNode(n))
+}
+
+// lookupWebURI resolves one of the WebURIs the fake hands out. Every user
+// shares the server's host, so site paths are looked up under the
+// authenticated user.
+func (s *Server) lookupWebURI(uri, webURI string) (*result, error) {
+	u, err := url.Parse(webURI)
+	if err != nil || u.Scheme+"://"+u.Host != s.URL {
+		return nil, errNotFound
+	}
+	if key := strings.TrimPrefix(u.Path, "/i-"); key != u.Path {
+		i, ok := s.images[key]
+		if !ok {
+			return nil, errNotFound
+		}
+		return object(uri, "Image", s.renderImage(i)), nil
+	}
+	usr, ok := s.users[s.authUser]
+	if !ok {
+		return nil, errNotFound
+	}
+	if strings.Trim(u.Path, "/") == "" {
+		return object(uri, "User", s.renderUser(usr)), nil
+	}
+	n := s.nodeAt(usr.NickName, u.Path)
+	if n == nil {
+		return nil, errNotFound
+	}
+	return s.nodeObject(uri, n), nil
+}
+
+// searchImages applies the UserImageSearch parameters in q to nickname's
+// images. Relevance is approximated by recency.
+func (s *Server) searchImages(nickname string, q url.Values) ([]*image, error) {
+	within := func(*image) bool { return true }
+	switch scope := strings.TrimPrefix(q.Get("Scope"), apiPrefix+"/"); {
+	case scope == "" || scope == "user/"+nickname:
+	case strings.HasPrefix(scope, "album/"):
+		key := strings.TrimPrefix(scope, "album/")
+		within = func(i *image) bool { return i.albumKey == key }
+	case strings.HasPrefix(scope, "node/"):
+		id := strings.TrimPrefix(scope, "node/")
+		within = func(i *image) bool {
+			for n := s.nodes[s.albums[i.albumKey].nodeID]; n != nil; n = s.nodes[n.parentID] {
+				if n.NodeID == id {
+					return true
+				}
+			}
+			return false
+		}
+	default:
+		return nil, &apiError{http.StatusBadRequest, "invalid Scope"}
+	}
+	var bounds [4]time.Time
+	for k, name := range []string{"DateTakenStart", "DateTakenEnd", "DateUploadedStart", "DateUploadedEnd"} {
+		if v := q.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, &apiError{http.StatusBadRequest, "invalid " + name}
+			}
+			bounds[k] = t
+		}
+	}
+	inRange := func(t *time.Time, lo, hi time.Time) bool {
+		if lo.IsZero() && hi.IsZero() {
+			return true
+		}
+		return t != nil && !t.Before(lo) && (hi.IsZero() || !t.After(hi))
+	}
+	words := strings.Fields(strings.ToLower(q.Get("Text")))
+	var keywords []string
+	for _, k := range strings.Split(q.Get("Keywords"), ",") {
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" {
+			keywords = append(keywords, k)
+		}
+	}
+
+	var ret []*image
+	for _, i := range s.userImages(nickname) {
+		have := map[string]bool{}
+		for _, k := range i.KeywordArray {
+			have[strings.ToLower(k)] = true
+		}
+		text := strings.ToLower(strings.Join([]string{i.Title, i.Caption, i.Keywords, i.FileName}, " "))
+		ok := within(i) && inRange(i.taken(), bounds[0], bounds[1]) && inRange(i.Date, bounds[2], bounds[3])
+		for _, w := range words {
+			ok = ok && strings.Contains(text, w)
+		}
+		for _, k := range keywords {
+			ok = ok && have[k]
+		}
+		if ok {
+			ret = append(ret, i)
+		}
+	}
+
+	// Undated images sort before dated ones.
+	before := func(a, b *time.Time) bool { return b != nil && (a == nil || a.Before(*b)) }
+	less := func(a, b *image) bool { return a.ImageKey < b.ImageKey }
+	switch q.Get("SortMethod") {
+	case "", "Relevance":
+	case "DateTaken":
+		less = func(a, b *image) bool { return before(a.taken(), b.taken()) }
+	case "DateUploaded":
+		less = func(a, b *image) bool { return before(a.Date, b.Date) }
+	case "Popular":
+		less = func(a, b *image) bool { return a.views < b.views }
+	default:
+		return nil, &apiError{http.StatusBadRequest, "invalid SortMethod"}
+	}
+	switch q.Get("SortDirection") {
+	case "", "Descending":
+		sort.SliceStable(ret, func(i, j int) bool { return less(ret[j], ret[i]) })
+	case "Ascending":
+		sort.SliceStable(ret, func(i, j int) bool { return less(ret[i], ret[j]) })
+	default:
+		return nil, &apiError{http.StatusBadRequest, "invalid SortDirection"}
+	}
+	return ret, nil
+}
+
+func collection(uri, locator string, items []interface{}, q url.Values) *result {
+	start, _ := strconv.Atoi(q.Get("start"))
+	if start < 1 {
+		start = 1
+	}
+	count, _ := strconv.Atoi(q.Get("count"))
+	if count < 1 {
+		count = 100
+	}
+	p := &pages{Total: len(items), Start: start, RequestedCount: count}
+	page := func(start int) string {
+		return fmt.Sprintf("%s?start=%d&count=%d", uri, start, count)
+	}
+	p.FirstPage = page(1)
+	if len(items) > 0 {
+		p.LastPage = page(((len(items)-1)/count)*count + 1)
+	}
+	lo := start - 1
+	if lo > len(items) {
+		lo = len(items)
+	}
+	hi := lo + count
+	if hi > len(items) {
+		hi = len(items)
+	} else if hi < len(items) {
+		p.NextPage = page(hi + 1)
+	}
+	if lo > 0 {
+		prev := lo - count + 1
+		if prev < 1 {
+			prev = 1
+		}
+		p.PrevPage = page(prev)
+	}
+	p.Count = hi - lo
+	value := items[lo:hi]
+	if value == nil {
+		value = []interface{}{}
+	}
+	return &result{uri: uri, locator: locator, locatorType: "Objects", value: value, pages: p}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*apiError)
+	if !ok {
+		e = &apiError{http.StatusInternalServerError, err.Error()}
+	}
+	writeJSON(w, e.code, map[string]interface{}{"Code": e.code, "Message": e.message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func splitAction(rel string) (string, string) {
+	if i := strings.Index(rel, "!"); i >= 0 {
+		return rel[:i], rel[i+1:]
+	}
+	return rel, ""
+}
+
+func splitList(s string) []string {
+	var ret []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func contains(list []string, s string) bool {
+	return indexOf(list, s) >= 0
+}
+
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// imageKey strips the "-N" serial suffix from an image URI key.
+func imageKey(key string) string {
+	if i := strings.LastIndex(key, "-"); i > 0 {
+		if _, err := strconv.Atoi(key[i+1:]); err == nil {
+			return key[:i]
+		}
+	}
+	return key
+}
@@ -0,0 +1,185 @@
+package smugmug
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timestampLayouts are tried in order. SmugMug returns RFC 3339 for its own
+// dates and EXIF-style values for metadata read from the file; fractional
+// seconds are accepted by time.Parse without appearing in the layout.
+var timestampLayouts = []struct {
+	layout string
+	zoned  bool
+}{
+	{time.RFC3339, true},
+	{"2006:01:02 15:04:05Z07:00", true},
+	{"2006-01-02 15:04:05Z07:00", true},
+	{"2006:01:02 15:04:05 -0700", true},
+	{"2006:01:02 15:04:05", false},
+	{"2006-01-02 15:04:05", false},
+	{"2006-01-02T15:04:05", false},
+	{"2006-01-02", false},
+	{"2006:01:02", false},
+}
+
+// Timestamp is a date as sent by SmugMug. Raw is what was received and is
+// marshaled back unchanged; Time is the parsed value. Values without a UTC
+// offset are parsed as UTC and have HasZone unset.
+type Timestamp struct {
+	Time    time.Time
+	HasZone bool
+	Raw     string
+}
+
+func NewTimestamp(t time.Time) *Timestamp {
+	return &Timestamp{Time: t, HasZone: true, Raw: t.Format(time.RFC3339)}
+}
+
+func ParseTimestamp(s string) (*Timestamp, error) {
+	ts := &Timestamp{Raw: s}
+	for _, l := range timestampLayouts {
+		if t, err := time.Parse(l.layout, s); err == nil {
+			ts.Time, ts.HasZone = t, l.zoned
+			return ts, nil
+		}
+	}
+	return ts, fmt.Errorf("smugmug: unrecognized timestamp %q", s)
+}
+
+func (t *Timestamp) IsZero() bool { return t == nil || t.Time.IsZero() }
+
+func (t Timestamp) String() string { return t.Raw }
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.Raw == "" && !t.Time.IsZero() {
+		return json.Marshal(t.Time.Format(time.RFC3339))
+	}
+	return json.Marshal(t.Raw)
+}
+
+// UnmarshalJSON keeps values it cannot parse in Raw rather than failing the
+// whole response; Time is left zero.
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	s, err := scalarString(b)
+	if err != nil {
+		return fmt.Errorf("smugmug: Timestamp: %v", err)
+	}
+	ts, _ := ParseTimestamp(s)
+	*t = *ts
+	return nil
+}
+
+// Coordinate is a latitude, longitude or altitude. Degrees is signed when the
+// API sends a signed value; ImageMetadata sends magnitudes and a separate
+// reference, see ImageMetadata.Location.
+type Coordinate struct {
+	Degrees float64
+	Raw     string
+}
+
+func (c Coordinate) String() string { return c.Raw }
+
+func (c Coordinate) MarshalJSON() ([]byte, error) {
+	if c.Raw == "" && c.Degrees != 0 {
+		return json.Marshal(strconv.FormatFloat(c.Degrees, 'f', -1, 64))
+	}
+	return json.Marshal(c.Raw)
+}
+
+func (c *Coordinate) UnmarshalJSON(b []byte) error {
+	s, err := scalarString(b)
+	if err != nil {
+		return fmt.Errorf("smugmug: Coordinate: %v", err)
+	}
+	*c = Coordinate{Raw: s}
+	c.Degrees, _ = strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return nil
+}
+
+// ExposureTime is a shutter speed such as "1/250", "0.5" or "2 sec".
+type ExposureTime struct {
+	Seconds float64
+	Raw     string
+}
+
+func (e ExposureTime) String() string { return e.Raw }
+
+func (e ExposureTime) MarshalJSON() ([]byte, error) { return json.Marshal(e.Raw) }
+
+func (e *ExposureTime) UnmarshalJSON(b []byte) error {
+	s, err := scalarString(b)
+	if err != nil {
+		return fmt.Errorf("smugmug: ExposureTime: %v", err)
+	}
+	*e = ExposureTime{Raw: s}
+	v := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "sec"))
+	v = strings.TrimSuffix(strings.TrimSuffix(v, "s"), "\"")
+	if num, den, ok := strings.Cut(v, "/"); ok {
+		n, err1 := strconv.ParseFloat(strings.TrimSpace(num), 64)
+		d, err2 := strconv.ParseFloat(strings.TrimSpace(den), 64)
+		if err1 == nil && err2 == nil && d != 0 {
+			e.Seconds = n / d
+		}
+		return nil
+	}
+	e.Seconds, _ = strconv.ParseFloat(strings.TrimSpace(v), 64)
+	return nil
+}
+
+// FocalLength is a lens focal length such as "35.0 mm".
+type FocalLength struct {
+	Millimeters float64
+	Raw         string
+}
+
+func (f FocalLength) String() string { return f.Raw }
+
+func (f FocalLength) MarshalJSON() ([]byte, error) { return json.Marshal(f.Raw) }
+
+func (f *FocalLength) UnmarshalJSON(b []byte) error {
+	s, err := scalarString(b)
+	if err != nil {
+		return fmt.Errorf("smugmug: FocalLength: %v", err)
+	}
+	*f = FocalLength{Raw: s}
+	v := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "mm"))
+	f.Millimeters, _ = strconv.ParseFloat(v, 64)
+	return nil
+}
+
+// scalarString returns a JSON string or number as text.
+func scalarString(b []byte) (string, error) {
+	if string(b) == "null" {
+		return "", nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		return s, nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return "", err
+	}
+	return n.String(), nil
+}
+
+// Location returns the signed latitude and longitude with LatitudeReference
+// and LongitudeReference applied. ok is false when either value is missing.
+func (m *ImageMetadata) Location() (lat, lon float64, ok bool) {
+	if m.Latitude == nil || m.Longitude == nil || m.Latitude.Raw == "" || m.Longitude.Raw == "" {
+		return 0, 0, false
+	}
+	lat, lon = m.Latitude.Degrees, m.Longitude.Degrees
+	if strings.HasPrefix(strings.ToUpper(m.LatitudeReference), "S") {
+		lat = -math.Abs(lat)
+	}
+	if strings.HasPrefix(strings.ToUpper(m.LongitudeReference), "W") {
+		lon = -math.Abs(lon)
+	}
+	return lat, lon, true
+}
@@ -0,0 +1,62 @@
+package smugmug
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestImageMetadataParsed(t *testing.T) {
+	data := []byte(`{"DateCreated":"2015-05-11","DateTimeCreated":"2015:05:11 20:01:44",` +
+		`"MicroDateTimeCreated":"2015:05:11 20:01:44.37","DateTimeModified":"2015-05-12T09:13:02-07:00",` +
+		`"Exposure":"1/250","FocalLength":"35.0 mm","FocalLength35mm":"52 mm",` +
+		`"Latitude":33.8688,"LatitudeReference":"S","Longitude":"151.2093","LongitudeReference":"E"}`)
+	var m ImageMetadata
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2015, 5, 11, 20, 1, 44, 0, time.UTC); !m.DateTimeCreated.Time.Equal(want) || m.DateTimeCreated.HasZone {
+		t.Errorf("DateTimeCreated = %v (zone %v)", m.DateTimeCreated.Time, m.DateTimeCreated.HasZone)
+	}
+	if got := m.MicroDateTimeCreated.Time.Nanosecond(); got != 370000000 {
+		t.Errorf("MicroDateTimeCreated nanoseconds = %d", got)
+	}
+	if want := time.Date(2015, 5, 12, 16, 13, 2, 0, time.UTC); !m.DateTimeModified.Time.Equal(want) || !m.DateTimeModified.HasZone {
+		t.Errorf("DateTimeModified = %v", m.DateTimeModified.Time)
+	}
+	if m.DateCreated.Time.Day() != 11 {
+		t.Errorf("DateCreated = %v", m.DateCreated.Time)
+	}
+	if m.Exposure.Seconds != 0.004 {
+		t.Errorf("Exposure = %v", m.Exposure.Seconds)
+	}
+	if m.FocalLength.Millimeters != 35 || m.FocalLength35mm.Millimeters != 52 {
+		t.Errorf("FocalLength = %v, %v", m.FocalLength.Millimeters, m.FocalLength35mm.Millimeters)
+	}
+	lat, lon, ok := m.Location()
+	if !ok || math.Abs(lat+33.8688) > 1e-9 || math.Abs(lon-151.2093) > 1e-9 {
+		t.Errorf("Location = %v, %v, %v", lat, lon, ok)
+	}
+
+	out, err := json.Marshal(&ImageMetadata{DateTimeCreated: m.DateTimeCreated, Exposure: m.Exposure})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"CopyrightUrl":"","DateTimeCreated":"2015:05:11 20:01:44","Exposure":"1/250"}`; string(out) != want {
+		t.Errorf("got %s, want %s", out, want)
+	}
+}
+
+func TestTimestampUnparsed(t *testing.T) {
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(`"sometime"`), &ts); err != nil {
+		t.Fatal(err)
+	}
+	if !ts.IsZero() || ts.Raw != "sometime" {
+		t.Errorf("got %+v", ts)
+	}
+	if _, err := ParseTimestamp("sometime"); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,101 @@
+package smugmug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Linked is implemented by the objects that carry Uris: Album, Comment,
+// Grant, Image, Node and User.
+type Linked interface {
+	uris() *URIs
+}
+
+type uriResponse struct {
+	Code       int
+	Message    string
+	Response   map[string]json.RawMessage
+	Expansions map[string]*json.RawMessage `json:",omitempty"`
+}
+
+var placeholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// URIParam fills the {name} placeholder of a templated URI passed to Get or
+// Follow.
+func URIParam(name, value string) CallOption {
+	return callOption(func(params url.Values) {
+		params.Set("{"+name+"}", value)
+	})
+}
+
+// getResponse is the response of Get: Value is the out it decoded into.
+type getResponse struct {
+	Locator string
+	Value   interface{}
+}
+
+// Get fetches the endpoint at uri, as found in an object's Uris or in a
+// paging link, and decodes its object or collection into out, e.g. a
+// **Album or a *[]*Image. uri may be absolute or relative to BasePath.
+func (s *Service) Get(ctx context.Context, uri string, out interface{}, opts ...CallOption) error {
+	params := url.Values{}
+	applyOptions(params, opts)
+	path, err := fillURI(uri, params)
+	if err != nil {
+		return err
+	}
+	c := newCall(s, "GET", path, "", func(r *getResponse) interface{} {
+		r.Value = out
+		return out
+	})
+	c.urlParams = params
+	_, err = c.Context(ctx).Do()
+	return err
+}
+
+// Follow fetches the endpoint obj links to under name, e.g.
+//
+//	details, err := Follow[*ImageSizeDetails](ctx, s, image, "ImageSizeDetails")
+//	children, err := Follow[[]*Node](ctx, s, node, "ChildNodes")
+func Follow[T any](ctx context.Context, s *Service, obj Linked, name string, opts ...CallOption) (T, error) {
+	var ret T
+	uris := obj.uris()
+	if uris == nil {
+		return ret, fmt.Errorf("smugmug: object has no Uris")
+	}
+	uri, ok := (*uris)[name]
+	if !ok {
+		return ret, fmt.Errorf("smugmug: object has no %q link", name)
+	}
+	err := s.Get(ctx, parseURI(uri), &ret, opts...)
+	return ret, err
+}
+
+// fillURI fills the template placeholders of uri from params and moves the
+// query parameters of uri that params does not set into params.
+func fillURI(uri string, params url.Values) (string, error) {
+	for k := range params {
+		if strings.HasPrefix(k, "{") {
+			uri = strings.Replace(uri, k, url.PathEscape(params.Get(k)), -1)
+			params.Del(k)
+		}
+	}
+	if p := placeholder.FindString(uri); p != "" {
+		return "", fmt.Errorf("smugmug: unresolved placeholder %s in %s", p, uri)
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	for k, v := range u.Query() {
+		if _, ok := params[k]; !ok {
+			params[k] = v
+		}
+	}
+	u.RawQuery = ""
+	return u.String(), nil
+}
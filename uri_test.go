@@ -0,0 +1,87 @@
+package smugmug_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestFollow(t *testing.T) {
+	fake, s, root := newFake(t)
+	a := fake.AddAlbum(root, &smugmug.Album{Name: "Paris"})
+	fake.AddAlbum(root, &smugmug.Album{Name: "Iceland"})
+	img := fake.AddImage(a.AlbumKey, &smugmug.Image{FileName: "eiffel.jpg", OriginalWidth: 6000}, []byte("jpeg"))
+
+	ctx := context.Background()
+	res, err := s.Images.GetImage(ctx, img.ImageKey+"-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	details, err := smugmug.Follow[*smugmug.ImageSizeDetails](ctx, s, res.Image, "ImageSizeDetails")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.ImageSizeOriginal == nil || details.ImageSizeOriginal.Width != 6000 {
+		t.Errorf("ImageSizeDetails = %+v", details)
+	}
+
+	album, err := smugmug.Follow[*smugmug.Album](ctx, s, res.Image, "ImageAlbum", smugmug.Expand("Node"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if album.AlbumKey != a.AlbumKey {
+		t.Errorf("ImageAlbum = %+v", album)
+	}
+	if n, ok := smugmug.ExpansionOf[*smugmug.Node](album.Expansions, "Node"); !ok || n.NodeID != a.NodeID {
+		t.Errorf("ImageAlbum.Expansions = %+v", album.Expansions)
+	}
+
+	children, err := smugmug.Follow[[]*smugmug.Node](ctx, s, album.Expansions["Node"].(*smugmug.Node), "ParentNodes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(children) != 1 || children[0].NodeID != root {
+		t.Errorf("ParentNodes = %+v", children)
+	}
+
+	if _, err := smugmug.Follow[*smugmug.Image](ctx, s, res.Image, "ImageComments"); err == nil {
+		t.Error("expected error for a missing link")
+	}
+}
+
+func TestGetTemplate(t *testing.T) {
+	_, s, root := newFake(t)
+	ctx := context.Background()
+	var node *smugmug.Node
+	if err := s.Get(ctx, "/api/v2/node/{NodeID}", &node); err == nil {
+		t.Error("expected error for an unresolved placeholder")
+	}
+	if err := s.Get(ctx, "/api/v2/node/{NodeID}", &node, smugmug.URIParam("NodeID", root)); err != nil {
+		t.Fatal(err)
+	}
+	if node.NodeID != root {
+		t.Errorf("node = %+v", node)
+	}
+	var children []*smugmug.Node
+	if err := s.Get(ctx, "node/"+root+"!children?count=5", &children); err != nil {
+		t.Fatal(err)
+	}
+	if len(children) != 0 {
+		t.Errorf("children = %+v", children)
+	}
+}
+
+func TestGetNilContext(t *testing.T) {
+	fake, s, root := newFake(t)
+	a := fake.AddAlbum(root, &smugmug.Album{Name: "Paris"})
+
+	var album *smugmug.Album
+	if err := s.Get(nil, "album/"+a.AlbumKey, &album); err != nil {
+		t.Fatal(err)
+	}
+	if album == nil || album.Name != "Paris" {
+		t.Errorf("album = %+v", album)
+	}
+}
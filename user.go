@@ -1,10 +1,9 @@
 package smugmug
 
 import (
+	"context"
 	"encoding/json"
-	"net/http"
-	"net/url"
-	"strings"
+	"time"
 )
 
 type UsersService struct {
@@ -17,17 +16,121 @@ func NewUsersService(s *Service) *UsersService {
 }
 
 func (r *UsersService) Get(id string) *UsersGetCall {
-	c := &UsersGetCall{s: r.s, urlParams: url.Values{}}
-	c.id = id
-	return c
+	return &UsersGetCall{newCall(r.s, "GET", "user/"+id, "User", func(res *UsersGetResponse) interface{} { return &res.User })}
 }
 
 func (r *UsersService) GetAuthUser() *UsersGetCall {
-	c := &UsersGetCall{s: r.s, urlParams: url.Values{}}
-	c.useAuthUser = true
+	return &UsersGetCall{newCall(r.s, "GET", "!authuser", "User", func(res *UsersGetResponse) interface{} { return &res.User })}
+}
+
+// SearchImages returns a call searching all of nickname's images for query,
+// which is matched against titles, captions, keywords and file names; an
+// empty query matches everything. Narrow it with Scope, Keywords and the
+// date setters; page it with Pages.
+func (r *UsersService) SearchImages(nickname, query string) *UsersImageSearchCall {
+	c := r.ImageSearch(nickname)
+	if query != "" {
+		c.Text(query)
+	}
 	return c
 }
 
+func (r *UsersService) GetUser(ctx context.Context, nickname string, opts ...CallOption) (*UsersGetResponse, error) {
+	c := r.Get(nickname).Context(ctx)
+	applyOptions(c.urlParams, opts)
+	return c.Do()
+}
+
+func (r *UsersService) AuthUser(ctx context.Context, opts ...CallOption) (*UsersGetResponse, error) {
+	c := r.GetAuthUser().Context(ctx)
+	applyOptions(c.urlParams, opts)
+	return c.Do()
+}
+
+func (r *UsersService) UserAlbums(ctx context.Context, nickname string, opts ...CallOption) ([]*Album, error) {
+	c := r.Albums(nickname)
+	applyOptions(c.urlParams, opts)
+	return allPages[*Album](ctx, c.Call)
+}
+
+func (r *UsersService) UserFeaturedAlbums(ctx context.Context, nickname string, opts ...CallOption) ([]*Album, error) {
+	c := r.FeaturedAlbums(nickname)
+	applyOptions(c.urlParams, opts)
+	return allPages[*Album](ctx, c.Call)
+}
+
+// UserRecentImages returns up to limit of nickname's most recently uploaded
+// images, or all of them if limit is not positive.
+func (r *UsersService) UserRecentImages(ctx context.Context, nickname string, limit int, opts ...CallOption) ([]*Image, error) {
+	c := r.RecentImages(nickname)
+	applyOptions(c.urlParams, opts)
+	return firstItems[*Image](ctx, c.Call, limit)
+}
+
+// UserPopularMedia returns up to limit of nickname's most viewed images, or
+// all of them if limit is not positive.
+func (r *UsersService) UserPopularMedia(ctx context.Context, nickname string, limit int, opts ...CallOption) ([]*Image, error) {
+	c := r.PopularMedia(nickname)
+	applyOptions(c.urlParams, opts)
+	return firstItems[*Image](ctx, c.Call, limit)
+}
+
+// ImageSearchQuery holds the criteria of UserImageSearch, which are those of
+// UsersImageSearchCall's setters. Zero fields are left out.
+type ImageSearchQuery struct {
+	Text              string
+	Scope             string // URI of a node or album to search below
+	Keywords          []string
+	DateTakenStart    time.Time
+	DateTakenEnd      time.Time
+	DateUploadedStart time.Time
+	DateUploadedEnd   time.Time
+	SortMethod        SearchSortMethod
+	SortDirection     SortDirection
+}
+
+// UserImageSearch returns up to limit of the images of nickname matching q,
+// or all of them if limit is not positive.
+func (r *UsersService) UserImageSearch(ctx context.Context, nickname string, q *ImageSearchQuery, limit int, opts ...CallOption) ([]*Image, error) {
+	c := r.SearchImages(nickname, "")
+	if q != nil {
+		q.apply(c)
+	}
+	applyOptions(c.urlParams, opts)
+	return firstItems[*Image](ctx, c.Call, limit)
+}
+
+func (q *ImageSearchQuery) apply(c *UsersImageSearchCall) {
+	if q.Text != "" {
+		c.Text(q.Text)
+	}
+	if q.Scope != "" {
+		c.Scope(q.Scope)
+	}
+	if len(q.Keywords) > 0 {
+		c.Keywords(q.Keywords)
+	}
+	for _, d := range []struct {
+		t   time.Time
+		set func(time.Time) *UsersImageSearchCall
+	}{
+		{q.DateTakenStart, c.DateTakenStart},
+		{q.DateTakenEnd, c.DateTakenEnd},
+		{q.DateUploadedStart, c.DateUploadedStart},
+		{q.DateUploadedEnd, c.DateUploadedEnd},
+	} {
+		if !d.t.IsZero() {
+			d.set(d.t)
+		}
+	}
+	if q.SortMethod != "" {
+		c.SortMethod(q.SortMethod)
+	}
+	if q.SortDirection != "" {
+		c.SortDirection(q.SortDirection)
+	}
+}
+
 type UsersServiceResponse struct {
 	Code     int
 	Message  string
@@ -39,71 +142,37 @@ type UsersServiceResponse struct {
 }
 
 type UsersGetCall struct {
-	id          string
-	useAuthUser bool
-
-	s         *Service
-	urlParams url.Values
+	*Call[UsersGetResponse]
 }
 
 func (c *UsersGetCall) Expand(expansions []string) *UsersGetCall {
-	c.urlParams.Set("_expand", strings.Join(expansions, ","))
+	c.Call.Expand(expansions)
 	return c
 }
 
 func (c *UsersGetCall) Filter(filter []string) *UsersGetCall {
-	c.urlParams.Set("_filter", strings.Join(filter, ","))
+	c.Call.Filter(filter)
 	return c
 }
 
-func (c *UsersGetCall) doRequest() (*http.Response, error) {
-	urls := resolveRelative(c.s.BasePath, "user/"+c.id)
-	if c.useAuthUser {
-		urls = strings.TrimRight(c.s.BasePath, "/") + "!authuser"
-	}
-	urls += "?" + encodeURLParams(c.urlParams)
-	req, _ := http.NewRequest("GET", urls, nil)
-	c.s.setHeaders(req)
-	debugRequest(req)
-	return c.s.client.Do(req)
+func (c *UsersGetCall) Fields(fields ...UserField) *UsersGetCall {
+	c.Call.Filter(fieldNames(fields))
+	return c
 }
 
-func (c *UsersGetCall) Do() (*UsersGetResponse, error) {
-	res, err := c.doRequest()
-	if err != nil {
-		return nil, err
-	}
-	debugResponse(res)
-	defer closeBody(res)
-	if err := checkResponse(res); err != nil {
-		return nil, err
-	}
-	usersRes := &UsersServiceResponse{}
-	if err := json.NewDecoder(res.Body).Decode(&usersRes); err != nil {
-		return nil, err
-	}
-	user := &User{}
-	if err := json.Unmarshal(*usersRes.Response.User, &user); err != nil {
-		return nil, err
-	}
-	exp, err := unmarshallExpansions(user.URIs, usersRes.Expansions)
-	if err != nil {
-		return nil, err
-	}
-	ret := &UsersGetResponse{
-		User: user,
-		ServerResponse: ServerResponse{
-			Header:         res.Header,
-			HTTPStatusCode: res.StatusCode,
-		},
-	}
-	for name, v := range exp {
-		switch name {
-		case "Node":
-			ret.Node = v.(*Node)
-		}
-	}
-	return ret, nil
+func (c *UsersGetCall) FilterURIs(names ...string) *UsersGetCall {
+	c.Call.FilterURIs(names...)
+	return c
+}
+
+func (c *UsersGetCall) ExpandTree(expansions ...*Expansion) *UsersGetCall {
+	c.Call.ExpandTree(expansions...)
+	return c
+}
+
+func (c *UsersGetCall) Context(ctx context.Context) *UsersGetCall {
+	c.Call.Context(ctx)
+	return c
 }
 
 type UsersGetResponse struct {
@@ -118,28 +187,31 @@ type UsersGetResponse struct {
 	// SortUserFeaturedAlbums *SortUserFeaturedAlbums
 	// UrlPathLookup          *UrlPathLookup
 	// UserAlbumTemplates     *UserAlbumTemplates
-	// UserAlbums             []*Album
+	UserAlbums []*Album
 	// UserContacts           *UserContacts
 	// UserCoupons            *UserCoupons
 	// UserDeletedAlbums      *UserDeletedAlbums
 	// UserDeletedFolders     *UserDeletedFolders
 	// UserDeletedPages       *UserDeletedPages
-	// UserFeaturedAlbums     *UserFeaturedAlbums
+	UserFeaturedAlbums []*Album
 	// UserGeoMedia           *UserGeoMedia
-	// UserGrants             *UserGrants
+	UserGrants []*Grant
 	// UserGuideStates        *UserGuideStates
 	// UserHideGuides         *UserHideGuides
-	// UserImageSearch        *UserImageSearch
+	UserImageSearch []*Image
 	// UserLatestQuickNews    *UserLatestQuickNews
-	// UserPopularMedia       *UserPopularMedia
+	UserPopularMedia []*Image
 	// UserPrintmarks         *UserPrintmarks
 	// UserProfile            *UserProfile
-	// UserRecentImages       *UserRecentImages
+	UserRecentImages []*Image
 	// UserTasks              *UserTasks
 	// UserTopKeywords        *UserTopKeywords
 	// UserUploadLimits       *UserUploadLimits
 	// UserWatermarks         *UserWatermarks
 
+	Other map[string]json.RawMessage `json:",omitempty"`
+
+	Requested      FieldSet `json:"-"`
 	ServerResponse `json:"-"`
 }
 
@@ -167,4 +239,7 @@ type User struct {
 	URI           string `json:"Uri,omitempty"`
 	URIs          *URIs  `json:"Uris,omitempty"`
 	WebURI        string `json:"WebUri,omitempty"`
+
+	Expansions Expansions                 `json:"-"`
+	Extra      map[string]json.RawMessage `json:"-"`
 }
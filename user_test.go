@@ -0,0 +1,48 @@
+package smugmug
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestUsersGet(t *testing.T) {
+	s := newTestService(t)
+	res, err := s.Users.Get("cmac").Expand([]string{"Node"}).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := res.User
+	if u.NickName != "cmac" || u.ImageCount != 1835 || u.FirstName != "REDACTED" {
+		t.Errorf("User = %+v", u)
+	}
+	if res.Node == nil || res.Node.NodeID != "XWx8t" || !res.Node.IsRoot {
+		t.Errorf("Node = %+v", res.Node)
+	}
+}
+
+func TestUsersGetAuthUser(t *testing.T) {
+	s := newTestService(t)
+	res, err := s.Users.GetAuthUser().Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.User.NickName != "cmac" {
+		t.Errorf("User = %+v", res.User)
+	}
+	if res.Node != nil {
+		t.Error("unexpected expansion")
+	}
+}
+
+func TestSearchImagesEmptyQuery(t *testing.T) {
+	s, err := New(http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Users.SearchImages("cmac", "").Keywords([]string{"bride"}).urlParams["Text"]; ok {
+		t.Error("empty query sent a Text parameter")
+	}
+	if got := s.Users.SearchImages("cmac", "cake").urlParams.Get("Text"); got != "cake" {
+		t.Errorf("Text = %q", got)
+	}
+}
@@ -0,0 +1,170 @@
+package smugmug_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestUserMedia(t *testing.T) {
+	fake, s, root := newFake(t)
+	var albums []*smugmug.Album
+	for _, name := range []string{"Paris", "Rome", "Oslo"} {
+		albums = append(albums, fake.AddAlbum(root, &smugmug.Album{Name: name}))
+	}
+	fake.SetFeatured(albums[1].AlbumKey, true)
+	var keys []string
+	for _, name := range []string{"a.jpg", "b.jpg", "c.jpg"} {
+		keys = append(keys, fake.AddImage(albums[0].AlbumKey, &smugmug.Image{FileName: name}, []byte(name)).ImageKey)
+	}
+	fake.SetViews(keys[1], 100)
+
+	ctx := context.Background()
+
+	var all []*smugmug.Album
+	err := s.Users.Albums(nickname).Count(2).Pages(ctx, func(res *smugmug.UsersAlbumsResponse) error {
+		all = append(all, res.UserAlbums...)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(all); got != "Paris, Rome, Oslo" {
+		t.Errorf("UserAlbums = %s", got)
+	}
+
+	featured, err := s.Users.FeaturedAlbums(nickname).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(featured.UserFeaturedAlbums) != 1 || featured.UserFeaturedAlbums[0].Name != "Rome" {
+		t.Errorf("UserFeaturedAlbums = %+v", featured.UserFeaturedAlbums)
+	}
+
+	recent, err := s.Users.RecentImages(nickname).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recent.UserRecentImages) != 3 || recent.UserRecentImages[0].FileName != "c.jpg" {
+		t.Errorf("UserRecentImages = %+v", recent.UserRecentImages)
+	}
+
+	popular, err := s.Users.PopularMedia(nickname).Count(1).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(popular.UserPopularMedia) != 1 || popular.UserPopularMedia[0].FileName != "b.jpg" || popular.Pages.Total != 3 {
+		t.Errorf("UserPopularMedia = %+v", popular.UserPopularMedia)
+	}
+
+	res, err := s.Users.GetUser(ctx, nickname, smugmug.Expand("UserAlbums", "UserFeaturedAlbums", "UserRecentImages", "UserPopularMedia"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.UserAlbums) != 3 || len(res.UserFeaturedAlbums) != 1 || len(res.UserRecentImages) != 3 || len(res.UserPopularMedia) != 3 {
+		t.Errorf("expansions: albums %d, featured %d, recent %d, popular %d",
+			len(res.UserAlbums), len(res.UserFeaturedAlbums), len(res.UserRecentImages), len(res.UserPopularMedia))
+	}
+}
+
+func TestUserImageSearch(t *testing.T) {
+	fake, s, root := newFake(t)
+	clients := fake.AddNode(root, &smugmug.Node{Name: "Clients", Type: smugmug.NodeTypeFolder})
+	wedding := fake.AddAlbum(clients.NodeID, &smugmug.Album{Name: "Smith Wedding"})
+	travel := fake.AddAlbum(root, &smugmug.Album{Name: "Travel"})
+
+	day := func(d int) *time.Time {
+		t := time.Date(2026, 6, d, 12, 0, 0, 0, time.UTC)
+		return &t
+	}
+	add := func(a *smugmug.Album, img *smugmug.Image, taken int) string {
+		key := fake.AddImage(a.AlbumKey, img, []byte(img.FileName)).ImageKey
+		fake.SetImageMetadata(key, &smugmug.ImageMetadata{DateTimeCreated: smugmug.NewTimestamp(*day(taken))})
+		return key
+	}
+	add(wedding, &smugmug.Image{FileName: "vows.jpg", Title: "Vows", KeywordArray: []string{"ceremony", "bride"}, Date: day(20)}, 2)
+	add(wedding, &smugmug.Image{FileName: "cake.jpg", Caption: "The cake", KeywordArray: []string{"reception"}, Date: day(21)}, 3)
+	add(travel, &smugmug.Image{FileName: "beach.jpg", Caption: "Wedding guests at the beach", Date: day(5)}, 4)
+
+	search := func(c *smugmug.UsersImageSearchCall) string {
+		t.Helper()
+		var found []*smugmug.Image
+		err := c.Count(1).Pages(context.Background(), func(res *smugmug.UsersImageSearchResponse) error {
+			found = append(found, res.UserImageSearch...)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return names(found)
+	}
+
+	for _, tt := range []struct {
+		name string
+		call *smugmug.UsersImageSearchCall
+		want string
+	}{
+		{"text", s.Users.SearchImages(nickname, "wedding"), "beach.jpg"},
+		{"keywords", s.Users.SearchImages(nickname, "").Keywords([]string{"Bride"}), "vows.jpg"},
+		{"scope", s.Users.SearchImages(nickname, "").Scope("/api/v2/node/" + clients.NodeID), "cake.jpg, vows.jpg"},
+		{"taken", s.Users.SearchImages(nickname, "").DateTakenStart(*day(3)).DateTakenEnd(*day(4)), "beach.jpg, cake.jpg"},
+		{"uploaded", s.Users.SearchImages(nickname, "").DateUploadedStart(*day(20)), "cake.jpg, vows.jpg"},
+		{"sort", s.Users.SearchImages(nickname, "").SortMethod(smugmug.SearchSortMethodDateUploaded).SortDirection(smugmug.SortDirectionAscending), "beach.jpg, vows.jpg, cake.jpg"},
+	} {
+		if got := search(tt.call); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := s.Users.SearchImages(nickname, "").SortMethod("Alphabetical").Do(); err == nil {
+		t.Error("unknown SortMethod: err = nil")
+	}
+}
+
+func TestUsersAPIMedia(t *testing.T) {
+	fake, s, root := newFake(t)
+	travel := fake.AddNode(root, &smugmug.Node{Name: "Travel", Type: smugmug.NodeTypeFolder})
+	paris := fake.AddAlbum(travel.NodeID, &smugmug.Album{Name: "Paris"})
+	rome := fake.AddAlbum(root, &smugmug.Album{Name: "Rome"})
+	fake.SetFeatured(rome.AlbumKey, true)
+	var keys []string
+	for _, name := range []string{"a.jpg", "b.jpg", "c.jpg"} {
+		keys = append(keys, fake.AddImage(paris.AlbumKey, &smugmug.Image{FileName: name, Title: "Louvre " + name}, []byte(name)).ImageKey)
+	}
+	fake.SetViews(keys[1], 10)
+	fake.SetViews(keys[2], 50)
+
+	var users smugmug.UsersAPI = s.Users
+	ctx := context.Background()
+	albums, err := users.UserAlbums(ctx, nickname)
+	if err != nil || names(albums) != "Paris, Rome" {
+		t.Errorf("UserAlbums = %s, %v", names(albums), err)
+	}
+	featured, err := users.UserFeaturedAlbums(ctx, nickname)
+	if err != nil || names(featured) != "Rome" {
+		t.Errorf("UserFeaturedAlbums = %s, %v", names(featured), err)
+	}
+	recent, err := users.UserRecentImages(ctx, nickname, 2)
+	if err != nil || names(recent) != "c.jpg, b.jpg" {
+		t.Errorf("UserRecentImages = %s, %v", names(recent), err)
+	}
+	popular, err := users.UserPopularMedia(ctx, nickname, 1)
+	if err != nil || names(popular) != "c.jpg" {
+		t.Errorf("UserPopularMedia = %s, %v", names(popular), err)
+	}
+	found, err := users.UserImageSearch(ctx, nickname, &smugmug.ImageSearchQuery{
+		Text:          "louvre",
+		Scope:         "/api/v2/node/" + travel.NodeID,
+		SortMethod:    smugmug.SearchSortMethodPopular,
+		SortDirection: smugmug.SortDirectionAscending,
+	}, 0)
+	if err != nil || names(found) != "a.jpg, b.jpg, c.jpg" {
+		t.Errorf("UserImageSearch = %s, %v", names(found), err)
+	}
+	res, err := users.LookupUserPath(ctx, nickname, "/Travel/Paris")
+	if err != nil || res.Album == nil || res.Album.AlbumKey != paris.AlbumKey {
+		t.Errorf("LookupUserPath = %+v, %v", res, err)
+	}
+}
@@ -0,0 +1,191 @@
+package smugmug
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Length limits the site enforces on node fields, in characters.
+const (
+	MaxNameLength     = 254
+	MaxURLNameLength  = 60
+	MaxPasswordLength = 50
+)
+
+var validURLName = regexp.MustCompile(`^[A-Z0-9][A-Za-z0-9-]*$`)
+
+// ValidationError is returned before a request is sent when the object to
+// be created has problems. Fields maps a field name to what is wrong with it.
+type ValidationError struct {
+	Fields map[string]string
+}
+
+func (e *ValidationError) Error() string {
+	var msgs []string
+	for name, problem := range e.Fields {
+		msgs = append(msgs, name+" "+problem)
+	}
+	sort.Strings(msgs)
+	return "smugmug: invalid fields: " + strings.Join(msgs, "; ")
+}
+
+// Validate reports every problem the site would reject n for when creating
+// it, as a *ValidationError. An empty UrlName is allowed as long as one can
+// be derived from Name.
+func (n *Node) Validate() error {
+	fields := map[string]string{}
+	switch n.Type {
+	case NodeTypeFolder, NodeTypeAlbum, NodeTypePage:
+	case "":
+		fields["Type"] = "is required"
+	default:
+		fields["Type"] = fmt.Sprintf("must be Folder, Album or Page, not %q", n.Type)
+	}
+	switch l := utf8.RuneCountInString(n.Name); {
+	case strings.TrimSpace(n.Name) == "":
+		fields["Name"] = "is required"
+	case l > MaxNameLength:
+		fields["Name"] = fmt.Sprintf("is %d characters, more than %d", l, MaxNameLength)
+	}
+	switch l := len(n.URLName); {
+	case n.URLName == "" && fields["Name"] == "" && URLName(n.Name) == "":
+		fields["UrlName"] = "cannot be derived from Name and must be set"
+	case n.URLName == "":
+	case l > MaxURLNameLength:
+		fields["UrlName"] = fmt.Sprintf("is %d characters, more than %d", l, MaxURLNameLength)
+	case !validURLName.MatchString(n.URLName):
+		fields["UrlName"] = "must start with a capital letter or digit and hold only letters, digits and hyphens"
+	}
+	if n.SecurityType == SecurityTypePassword && n.Password == "" {
+		fields["Password"] = "is required when SecurityType is Password"
+	} else if l := utf8.RuneCountInString(n.Password); l > MaxPasswordLength {
+		fields["Password"] = fmt.Sprintf("is %d characters, more than %d", l, MaxPasswordLength)
+	}
+	// Folders sort their children and albums their images, each with its
+	// own methods; pages hold neither.
+	switch n.Type {
+	case NodeTypeFolder:
+		switch n.SortMethod {
+		case "", SortMethodSortIndex, SortMethodName, SortMethodDateAdded, SortMethodDateModified:
+		default:
+			fields["SortMethod"] = fmt.Sprintf("%q does not apply to folders", n.SortMethod)
+		}
+	case NodeTypeAlbum:
+		switch n.SortMethod {
+		case "", SortMethodPosition, SortMethodCaption, SortMethodFileName, SortMethodDateUploaded,
+			SortMethodImageDateModified, SortMethodDateTaken:
+		default:
+			fields["SortMethod"] = fmt.Sprintf("%q does not apply to albums", n.SortMethod)
+		}
+	case NodeTypePage:
+		if n.SortMethod != "" {
+			fields["SortMethod"] = "does not apply to pages"
+		}
+		if n.SortDirection != "" {
+			fields["SortDirection"] = "does not apply to pages"
+		}
+	}
+	for name, v := range map[string]interface{ Valid() bool }{
+		"Privacy":         n.Privacy,
+		"SecurityType":    n.SecurityType,
+		"SmugSearchable":  n.SmugSearchable,
+		"SortDirection":   n.SortDirection,
+		"SortMethod":      n.SortMethod,
+		"WorldSearchable": n.WorldSearchable,
+	} {
+		if fmt.Sprint(v) != "" && !v.Valid() && fields[name] == "" {
+			fields[name] = fmt.Sprintf("has unknown value %q", v)
+		}
+	}
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// URLName derives a UrlName from a node name the way the site does: accented
+// letters are folded to ASCII, other punctuation and spaces separate words,
+// and the capitalized words are joined by hyphens, e.g. "smith & café
+// wedding" becomes "Smith-Cafe-Wedding". Names longer than MaxURLNameLength
+// are cut at a word boundary. The result is empty if name has no letters or
+// digits that fold to ASCII.
+func URLName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		s := string(r)
+		if r >= utf8.RuneSelf {
+			s = asciiFold[r]
+		}
+		if s == "" || !isAlnum(s) {
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "-") {
+				b.WriteByte('-')
+			}
+			upper = true
+			continue
+		}
+		if upper {
+			s = strings.ToUpper(s[:1]) + s[1:]
+			upper = false
+		}
+		b.WriteString(s)
+	}
+	ret := strings.TrimSuffix(b.String(), "-")
+	if len(ret) > MaxURLNameLength {
+		// Unless the cut falls between words, drop the partial last word.
+		atBoundary := ret[MaxURLNameLength] == '-'
+		ret = ret[:MaxURLNameLength]
+		if i := strings.LastIndexByte(ret, '-'); i > 0 && !atBoundary {
+			ret = ret[:i]
+		}
+		ret = strings.TrimSuffix(ret, "-")
+	}
+	return ret
+}
+
+func isAlnum(s string) bool {
+	for _, r := range s {
+		if r >= utf8.RuneSelf || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// asciiFold maps the Latin letters with diacritics and ligatures likely in
+// node names to their ASCII spelling.
+var asciiFold = map[rune]string{}
+
+func init() {
+	for ascii, letters := range map[string]string{
+		"A": "ÀÁÂÃÄÅĀĂĄ", "a": "àáâãäåāăą",
+		"C": "ÇĆĈĊČ", "c": "çćĉċč",
+		"D": "ĎĐÐ", "d": "ďđð",
+		"E": "ÈÉÊËĒĔĖĘĚ", "e": "èéêëēĕėęě",
+		"G": "ĜĞĠĢ", "g": "ĝğġģ",
+		"H": "ĤĦ", "h": "ĥħ",
+		"I": "ÌÍÎÏĨĪĬĮİ", "i": "ìíîïĩīĭįı",
+		"J": "Ĵ", "j": "ĵ",
+		"K": "Ķ", "k": "ķ",
+		"L": "ĹĻĽĿŁ", "l": "ĺļľŀł",
+		"N": "ÑŃŅŇ", "n": "ñńņň",
+		"O": "ÒÓÔÕÖØŌŎŐ", "o": "òóôõöøōŏő",
+		"R": "ŔŖŘ", "r": "ŕŗř",
+		"S": "ŚŜŞŠ", "s": "śŝşš",
+		"T": "ŢŤŦ", "t": "ţťŧ",
+		"U": "ÙÚÛÜŨŪŬŮŰŲ", "u": "ùúûüũūŭůűų",
+		"W": "Ŵ", "w": "ŵ",
+		"Y": "ÝŶŸ", "y": "ýÿŷ",
+		"Z": "ŹŻŽ", "z": "źżž",
+		"AE": "Æ", "ae": "æ", "OE": "Œ", "oe": "œ",
+		"TH": "Þ", "th": "þ", "ss": "ß",
+	} {
+		for _, r := range letters {
+			asciiFold[r] = ascii
+		}
+	}
+}
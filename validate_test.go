@@ -0,0 +1,93 @@
+package smugmug_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pilwon/go-smugmug"
+)
+
+func TestURLName(t *testing.T) {
+	for _, tt := range []struct{ name, want string }{
+		{"Smith Wedding", "Smith-Wedding"},
+		{"  smith & jones -- 2026 ", "Smith-Jones-2026"},
+		{"Café Crème", "Cafe-Creme"},
+		{"Straße Œuvre", "Strasse-OEuvre"},
+		{"Kraków/Łódź", "Krakow-Lodz"},
+		{"東京", ""},
+		{"東京 2026", "2026"},
+		{strings.Repeat("word ", 20), strings.TrimSuffix(strings.Repeat("Word-", 12), "-")},
+		{strings.Repeat("a", 47) + " " + strings.Repeat("b", 12) + " tail", "A" + strings.Repeat("a", 46) + "-B" + strings.Repeat("b", 11)},
+	} {
+		if got := smugmug.URLName(tt.name); got != tt.want {
+			t.Errorf("URLName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNodeValidate(t *testing.T) {
+	if err := (&smugmug.Node{Name: "Smith Wedding", Type: smugmug.NodeTypeAlbum}).Validate(); err != nil {
+		t.Errorf("valid album: %v", err)
+	}
+	err := (&smugmug.Node{
+		Name:         strings.Repeat("x", smugmug.MaxNameLength+1),
+		URLName:      "smith-wedding",
+		SecurityType: smugmug.SecurityTypePassword,
+		SortMethod:   smugmug.SortMethodDateTaken,
+		Type:         smugmug.NodeTypeFolder,
+		Privacy:      "Secret",
+	}).Validate()
+	var verr *smugmug.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("err = %v, want *ValidationError", err)
+	}
+	for _, f := range []string{"Name", "UrlName", "Password", "SortMethod", "Privacy"} {
+		if verr.Fields[f] == "" {
+			t.Errorf("no problem reported for %s in %v", f, err)
+		}
+	}
+	if len(verr.Fields) != 5 {
+		t.Errorf("got %d problems: %v", len(verr.Fields), err)
+	}
+
+	for _, n := range []*smugmug.Node{
+		{Name: "Smith Wedding", Type: smugmug.NodeTypeAlbum, SortMethod: smugmug.SortMethodName},
+		{Name: "About", Type: smugmug.NodeTypePage, SortMethod: smugmug.SortMethodName},
+	} {
+		if err := n.Validate(); !errors.As(err, &verr) || verr.Fields["SortMethod"] == "" {
+			t.Errorf("%s sorted by %s: err = %v", n.Type, n.SortMethod, err)
+		}
+	}
+	if err := (&smugmug.Node{Name: "Smith Wedding", Type: smugmug.NodeTypeAlbum, SortMethod: smugmug.SortMethodDateTaken}).Validate(); err != nil {
+		t.Errorf("album sorted by date taken: %v", err)
+	}
+
+	err = (&smugmug.Node{Name: "東京"}).Validate()
+	if !errors.As(err, &verr) || verr.Fields["Type"] == "" || verr.Fields["UrlName"] == "" {
+		t.Errorf("err = %v, want Type and UrlName problems", err)
+	}
+}
+
+func TestNodesCreateValidates(t *testing.T) {
+	fake, s, root := newFake(t)
+	ctx := context.Background()
+
+	var verr *smugmug.ValidationError
+	if _, err := s.Nodes.CreateNode(ctx, root, &smugmug.Node{Type: smugmug.NodeTypeAlbum}); !errors.As(err, &verr) {
+		t.Errorf("err = %v, want *ValidationError", err)
+	}
+	if fake.Node(root).HasChildren {
+		t.Error("invalid node was sent")
+	}
+
+	node := &smugmug.Node{Name: "Café Déjà Vu", Type: smugmug.NodeTypeAlbum}
+	n, err := s.Nodes.CreateNode(ctx, root, node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.URLName != "Cafe-Deja-Vu" || node.URLName != "" {
+		t.Errorf("UrlName = %q, caller's node = %q", n.URLName, node.URLName)
+	}
+}